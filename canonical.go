@@ -0,0 +1,206 @@
+package jsonic
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Equal reports whether the data of the json trees is the same, comparing the numbers
+// numerically and the objects irrespective of the order of their keys.
+func (j *Jsonic) Equal(other *Jsonic) bool {
+	return equalValues(j.value(), other.value())
+}
+
+// Canonical returns the canonical json encoding of the data, as per the json
+// canonicalization scheme of RFC 8785.
+//
+// The output is compact, with the keys of the objects sorted by their UTF-16 code
+// units, the strings escaping only the characters that must be escaped, and the numbers
+// written as done in ECMAScript. As the numbers are written as float64, it returns
+// ErrOverflow in case any of them does not fit in a float64.
+func (j *Jsonic) Canonical() ([]byte, error) {
+	return appendCanonical(nil, j.value())
+}
+
+// Hash returns the SHA-256 digest of the canonical json encoding of the data, as returned
+// by Canonical. So the json trees equal as per Equal have the same hash, which is also
+// the same as the one computed from the canonical json encoding in any other language.
+func (j *Jsonic) Hash() ([]byte, error) {
+	b, err := j.Canonical()
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(b)
+	return digest[:], nil
+}
+
+func appendCanonical(dst []byte, data interface{}) ([]byte, error) {
+	switch v := data.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case bool:
+		return strconv.AppendBool(dst, v), nil
+	case string:
+		return appendCanonicalString(dst, v), nil
+	case float64, json.Number:
+		f := numberAsFloat64(v)
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, ErrOverflow
+		}
+		return appendCanonicalNumber(dst, f), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(a, b int) bool {
+			return lessUTF16(keys[a], keys[b])
+		})
+		dst = append(dst, '{')
+		for i, k := range keys {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendCanonicalString(dst, k)
+			dst = append(dst, ':')
+			var err error
+			dst, err = appendCanonical(dst, v[k])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, '}'), nil
+	case []interface{}:
+		dst = append(dst, '[')
+		for i, e := range v {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			var err error
+			dst, err = appendCanonical(dst, e)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, ']'), nil
+	}
+	// not something unmarshalled from json
+	return nil, ErrInvalidType
+}
+
+func appendCanonicalString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			dst = utf8.AppendRune(dst, r)
+			i += size
+			continue
+		}
+		switch c {
+		case '"', '\\':
+			dst = append(dst, '\\', c)
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		default:
+			if c < 0x20 {
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			} else {
+				dst = append(dst, c)
+			}
+		}
+		i++
+	}
+	return append(dst, '"')
+}
+
+// appendCanonicalNumber appends the finite number as done by Number.prototype.toString in ECMAScript.
+func appendCanonicalNumber(dst []byte, f float64) []byte {
+	if f == 0 {
+		// including the negative zero
+		return append(dst, '0')
+	}
+	if f < 0 {
+		dst = append(dst, '-')
+		f = -f
+	}
+	// the shortest digits which are parsed back to the same number, like 1.2345e+06
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(s, "e")
+	digits := strings.Replace(mantissa, dot, empty, 1)
+	e, _ := strconv.Atoi(exp)
+	// the position of the decimal point in the digits
+	n := e + 1
+	k := len(digits)
+	switch {
+	case k <= n && n <= 21:
+		dst = append(dst, digits...)
+		for i := k; i < n; i++ {
+			dst = append(dst, '0')
+		}
+	case 0 < n && n <= 21:
+		dst = append(dst, digits[:n]...)
+		dst = append(dst, '.')
+		dst = append(dst, digits[n:]...)
+	case -6 < n && n <= 0:
+		dst = append(dst, '0', '.')
+		for i := n; i < 0; i++ {
+			dst = append(dst, '0')
+		}
+		dst = append(dst, digits...)
+	default:
+		dst = append(dst, digits[0])
+		if k > 1 {
+			dst = append(dst, '.')
+			dst = append(dst, digits[1:]...)
+		}
+		dst = append(dst, 'e')
+		if e > 0 {
+			dst = append(dst, '+')
+		}
+		dst = strconv.AppendInt(dst, int64(e), 10)
+	}
+	return dst
+}
+
+// lessUTF16 reports whether the first string is less than the other one, comparing their UTF-16 code units.
+func lessUTF16(a, b string) bool {
+	for a != empty && b != empty {
+		ra, sizeA := utf8.DecodeRuneInString(a)
+		rb, sizeB := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			return firstUnit(ra) < firstUnit(rb) || (firstUnit(ra) == firstUnit(rb) && secondUnit(ra) < secondUnit(rb))
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	return a == empty && b != empty
+}
+
+func firstUnit(r rune) rune {
+	if r1, _ := utf16.EncodeRune(r); r1 != utf8.RuneError {
+		return r1
+	}
+	return r
+}
+
+func secondUnit(r rune) rune {
+	if _, r2 := utf16.EncodeRune(r); r2 != utf8.RuneError {
+		return r2
+	}
+	return 0
+}
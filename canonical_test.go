@@ -0,0 +1,128 @@
+package jsonic_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	a := mustNew(t, `{"a": [1, {"b": 2.0, "c": null}], "d": "x"}`)
+	assert.True(t, a.Equal(mustNew(t, `{"d": "x", "a": [1.0, {"c": null, "b": 2}]}`)))
+	assert.True(t, a.Equal(a))
+	assert.False(t, a.Equal(mustNew(t, `{"a": [{"b": 2.0, "c": null}, 1], "d": "x"}`)))
+	assert.False(t, a.Equal(mustNew(t, `{"a": [1, {"b": 2.0}], "d": "x"}`)))
+	assert.False(t, a.Equal(mustNew(t, `{"a": [1, {"b": "2", "c": null}], "d": "x"}`)))
+	assert.True(t, mustChild(t, a, "a[1].b").Equal(mustNew(t, `2`)))
+
+	x, err := jsonic.NewWithOptions([]byte(`[9007199254740993, 1e2]`), jsonic.UseNumber(), jsonic.Lazy())
+	assert.NoError(t, err)
+	y, err := jsonic.NewWithOptions([]byte(`[9007199254740993, 100]`), jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.True(t, x.Equal(y))
+	y, err = jsonic.NewWithOptions([]byte(`[9007199254740992, 100]`), jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.False(t, x.Equal(y))
+}
+
+func TestCanonical(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test18.json", t))
+	assert.NoError(t, err)
+
+	b, err := mustChild(t, j, "numbers").Canonical()
+	assert.NoError(t, err)
+	assert.Equal(t, `[333333333.3333333,1e+30,4.5,0.002,1e-27]`, string(b))
+	b, err = mustChild(t, j, "string").Canonical()
+	assert.NoError(t, err)
+	assert.Equal(t, "\"\u20ac$\\u000f\\nA'B\\\"\\\\\\\\\\\"/\"", string(b))
+	b, err = mustChild(t, j, "literals").Canonical()
+	assert.NoError(t, err)
+	assert.Equal(t, `[null,true,false]`, string(b))
+	b, err = mustChild(t, j, "sorting").Canonical()
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\","+
+		"\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\","+
+		"\"\U0001F600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}", string(b))
+
+	b, err = mustNew(t, `{"b": "<&>\u2028\u001f\b\f\t", "a": {"y": [], "x": {}}}`).Canonical()
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"a\":{\"x\":{},\"y\":[]},\"b\":\"<&>\u2028\\u001f\\b\\f\\t\"}", string(b))
+}
+
+func TestCanonicalNumbers(t *testing.T) {
+	// the test vectors from the appendix B of RFC 8785
+	cases := map[uint64]string{
+		0x0000000000000000: "0",
+		0x8000000000000000: "0",
+		0x0000000000000001: "5e-324",
+		0x8000000000000001: "-5e-324",
+		0x7fefffffffffffff: "1.7976931348623157e+308",
+		0xffefffffffffffff: "-1.7976931348623157e+308",
+		0x4340000000000000: "9007199254740992",
+		0xc340000000000000: "-9007199254740992",
+		0x4430000000000000: "295147905179352830000",
+		0x44b52d02c7e14af5: "9.999999999999997e+22",
+		0x44b52d02c7e14af6: "1e+23",
+		0x44b52d02c7e14af7: "1.0000000000000001e+23",
+		0x444b1ae4d6e2ef4e: "999999999999999700000",
+		0x444b1ae4d6e2ef4f: "999999999999999900000",
+		0x444b1ae4d6e2ef50: "1e+21",
+		0x3eb0c6f7a0b5ed8c: "9.999999999999997e-7",
+		0x3eb0c6f7a0b5ed8d: "0.000001",
+		0x41b3de4355555553: "333333333.3333332",
+		0x41b3de4355555554: "333333333.33333325",
+		0x41b3de4355555555: "333333333.3333333",
+		0x41b3de4355555556: "333333333.3333334",
+		0x41b3de4355555557: "333333333.33333343",
+		0xbecbf647612f3696: "-0.0000033333333333333333",
+		0x43143ff3c1cb0959: "1424953923781206.2",
+	}
+	for bits, expected := range cases {
+		f := math.Float64frombits(bits)
+		for _, opts := range [][]jsonic.Option{nil, {jsonic.UseNumber()}} {
+			j, err := jsonic.NewWithOptions([]byte(strconv.FormatFloat(f, 'g', -1, 64)), opts...)
+			assert.NoError(t, err)
+			b, err := j.Canonical()
+			assert.NoError(t, err)
+			assert.Equal(t, expected, string(b), strconv.FormatUint(bits, 16))
+		}
+	}
+
+	j, err := jsonic.NewWithOptions([]byte(`[1e400]`), jsonic.UseNumber())
+	assert.NoError(t, err)
+	_, err = j.Canonical()
+	assert.Equal(t, jsonic.ErrOverflow, err)
+	_, err = j.Hash()
+	assert.Equal(t, jsonic.ErrOverflow, err)
+}
+
+func TestHash(t *testing.T) {
+	a := mustNew(t, `{"b": [1, 2.50], "a": "x"}`)
+	h, err := a.Hash()
+	assert.NoError(t, err)
+	expected := sha256.Sum256([]byte(`{"a":"x","b":[1,2.5]}`))
+	assert.Equal(t, hex.EncodeToString(expected[:]), hex.EncodeToString(h))
+
+	// the equal json trees have the same hash
+	b, err := jsonic.NewWithOptions([]byte(`{"a": "x", "b": [1.0, 25e-1]}`), jsonic.UseNumber(), jsonic.Lazy())
+	assert.NoError(t, err)
+	assert.True(t, a.Equal(b))
+	other, err := b.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, h, other)
+
+	// the hash of a child is of its data
+	child, err := mustChild(t, a, "b").Hash()
+	assert.NoError(t, err)
+	expected = sha256.Sum256([]byte(`[1,2.5]`))
+	assert.Equal(t, expected[:], child)
+
+	other, err = mustNew(t, `{"b": [2.5, 1], "a": "x"}`).Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, h, other)
+}
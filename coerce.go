@@ -0,0 +1,274 @@
+package jsonic
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// kinds of the json data which can be coerced to the scalars
+const coercible = KindNull | KindBool | KindNumber | KindString
+
+// AsInt is used to get the integer at the path specified, converting the data if required.
+//
+// A number should be an integer, otherwise ErrPrecision is returned instead
+// of truncating it. A string should contain such a number, like "42" or "4e1".
+// The booleans true and false are converted to 1 and 0, and null to 0.
+func (j *Jsonic) AsInt(path string) (int, error) {
+	return compilePath(path).AsInt(j)
+}
+
+// AsInt64 is used to get the 64-bit integer at the path specified, converting the data
+// if required, in the same way as it is done in AsInt.
+func (j *Jsonic) AsInt64(path string) (int64, error) {
+	return compilePath(path).AsInt64(j)
+}
+
+// AsFloat64 is used to get the floating point number at the path specified, converting the data if required.
+//
+// A string should contain a number, like "4.2". The booleans true and false
+// are converted to 1 and 0, and null to 0.
+func (j *Jsonic) AsFloat64(path string) (float64, error) {
+	return compilePath(path).AsFloat64(j)
+}
+
+// AsBool is used to get the boolean at the path specified, converting the data if required.
+//
+// The numbers 1 and 0 are converted to true and false. The strings "true", "t",
+// "yes", "y", "on" and "1" are converted to true, while "false", "f", "no", "n",
+// "off" and "0" are converted to false, ignoring the case and the surrounding spaces.
+// Null is converted to false.
+func (j *Jsonic) AsBool(path string) (bool, error) {
+	return compilePath(path).AsBool(j)
+}
+
+// AsString is used to get the string at the path specified, converting the data if required.
+//
+// The numbers and the booleans are converted to their json representation,
+// like "4.2" and "true", and null to the empty string.
+func (j *Jsonic) AsString(path string) (string, error) {
+	return compilePath(path).AsString(j)
+}
+
+// AsDuration is used to get the duration at the path specified, converting the data if required.
+//
+// A string can either be a duration like "1h30m", as accepted by time.ParseDuration,
+// or a number. The numbers are the seconds, like 1.5 for one and a half seconds,
+// and null is converted to 0.
+func (j *Jsonic) AsDuration(path string) (time.Duration, error) {
+	return compilePath(path).AsDuration(j)
+}
+
+// AsTime is used to get the time at the path specified, converting the data if required.
+//
+// A string is parsed using the layouts provided, which are tried in order,
+// and by default it is RFC 3339 like "2006-01-02T15:04:05Z07:00". It can
+// also be a number. The numbers are the seconds since the unix epoch, converted
+// to a time in UTC, and null is converted to the zero time.
+func (j *Jsonic) AsTime(path string, layouts ...string) (time.Time, error) {
+	return compilePath(path).AsTime(j, layouts...)
+}
+
+// AsInt is used to get the integer at this path, converting the data if required,
+// in the same way as it is done by Jsonic.AsInt.
+func (p *Path) AsInt(j *Jsonic) (int, error) {
+	val, err := p.Get(j)
+	if err != nil {
+		return 0, err
+	}
+	i, err := coerceInt64(val, strconv.IntSize)
+	return int(i), p.typeError(err, val, coercible)
+}
+
+// AsInt64 is used to get the 64-bit integer at this path, converting the data if required,
+// in the same way as it is done by Jsonic.AsInt.
+func (p *Path) AsInt64(j *Jsonic) (int64, error) {
+	val, err := p.Get(j)
+	if err != nil {
+		return 0, err
+	}
+	i, err := coerceInt64(val, 64)
+	return i, p.typeError(err, val, coercible)
+}
+
+// AsFloat64 is used to get the floating point number at this path, converting the data if required,
+// in the same way as it is done by Jsonic.AsFloat64.
+func (p *Path) AsFloat64(j *Jsonic) (float64, error) {
+	val, err := p.Get(j)
+	if err != nil {
+		return 0, err
+	}
+	f, err := coerceFloat64(val)
+	return f, p.typeError(err, val, coercible)
+}
+
+// AsBool is used to get the boolean at this path, converting the data if required,
+// in the same way as it is done by Jsonic.AsBool.
+func (p *Path) AsBool(j *Jsonic) (bool, error) {
+	val, err := p.Get(j)
+	if err != nil {
+		return false, err
+	}
+	b, err := coerceBool(val)
+	return b, p.typeError(err, val, coercible)
+}
+
+// AsString is used to get the string at this path, converting the data if required,
+// in the same way as it is done by Jsonic.AsString.
+func (p *Path) AsString(j *Jsonic) (string, error) {
+	val, err := p.Get(j)
+	if err != nil {
+		return "", err
+	}
+	s, err := coerceString(val)
+	return s, p.typeError(err, val, coercible)
+}
+
+// AsDuration is used to get the duration at this path, converting the data if required,
+// in the same way as it is done by Jsonic.AsDuration.
+func (p *Path) AsDuration(j *Jsonic) (time.Duration, error) {
+	val, err := p.Get(j)
+	if err != nil {
+		return 0, err
+	}
+	d, err := coerceDuration(val)
+	return d, p.typeError(err, val, KindNull|KindNumber|KindString)
+}
+
+// AsTime is used to get the time at this path, converting the data if required,
+// in the same way as it is done by Jsonic.AsTime.
+func (p *Path) AsTime(j *Jsonic, layouts ...string) (time.Time, error) {
+	val, err := p.Get(j)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := coerceTime(val, layouts)
+	return t, p.typeError(err, val, KindNull|KindNumber|KindString)
+}
+
+// coerceNumber converts the data to a json number, in case it is a number,
+// a string containing a number, a boolean or null.
+func coerceNumber(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case nil:
+		return float64(0), nil
+	case bool:
+		if v {
+			return float64(1), nil
+		}
+		return float64(0), nil
+	case float64, json.Number:
+		return v, nil
+	case string:
+		s := strings.TrimSpace(v)
+		if isNumberLiteral(s) {
+			return json.Number(s), nil
+		}
+	}
+	return nil, ErrInvalidType
+}
+
+func coerceInt64(val interface{}, bitSize int) (int64, error) {
+	n, err := coerceNumber(val)
+	if err != nil {
+		return 0, err
+	}
+	return toInt64(n, bitSize)
+}
+
+func coerceFloat64(val interface{}) (float64, error) {
+	n, err := coerceNumber(val)
+	if err != nil {
+		return 0, err
+	}
+	return toFloat64(n, 64)
+}
+
+func coerceBool(val interface{}) (bool, error) {
+	switch v := val.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case float64, json.Number:
+		if compareNumbers(v, float64(0)) == 0 {
+			return false, nil
+		}
+		if compareNumbers(v, float64(1)) == 0 {
+			return true, nil
+		}
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "t", "yes", "y", "on", "1":
+			return true, nil
+		case "false", "f", "no", "n", "off", "0":
+			return false, nil
+		}
+	}
+	return false, ErrInvalidType
+}
+
+func coerceString(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return empty, nil
+	case string:
+		return v, nil
+	case bool, float64, json.Number:
+		e := newEncoder(nil)
+		err := e.encode(v, 0)
+		if err != nil {
+			return empty, err
+		}
+		return string(e.buf), nil
+	}
+	return empty, ErrInvalidType
+}
+
+func coerceDuration(val interface{}) (time.Duration, error) {
+	if s, ok := val.(string); ok {
+		if d, err := time.ParseDuration(strings.TrimSpace(s)); err == nil {
+			return d, nil
+		}
+	}
+	if _, ok := val.(bool); ok {
+		return 0, ErrInvalidType
+	}
+	seconds, err := coerceFloat64(val)
+	if err != nil {
+		return 0, err
+	}
+	ns, err := floatToInt64(math.Round(seconds*float64(time.Second)), 64)
+	return time.Duration(ns), err
+}
+
+func coerceTime(val interface{}, layouts []string) (time.Time, error) {
+	switch v := val.(type) {
+	case nil:
+		return time.Time{}, nil
+	case bool:
+		return time.Time{}, ErrInvalidType
+	case string:
+		if len(layouts) == 0 {
+			layouts = []string{time.RFC3339Nano}
+		}
+		s := strings.TrimSpace(v)
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+	}
+	seconds, err := coerceFloat64(val)
+	if err != nil {
+		return time.Time{}, err
+	}
+	whole := math.Floor(seconds)
+	sec, err := floatToInt64(whole, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, int64(math.Round((seconds-whole)*1e9))).UTC(), nil
+}
@@ -0,0 +1,177 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsInt(t *testing.T) {
+	j, err := jsonic.NewWithOptions(readFromFile("test_data/test11.json", t), jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, expected := range map[string]int{
+		"int":       42,
+		"intString": 42,
+		"expString": 40,
+		"true":      1,
+		"false":     0,
+		"null":      0,
+	} {
+		i, err := j.AsInt(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, i, path)
+	}
+	i, err := j.AsInt64("big")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9223372036854775807), i)
+
+	for path, expected := range map[string]error{
+		"float":       jsonic.ErrPrecision,
+		"floatString": jsonic.ErrPrecision,
+		"bigString":   jsonic.ErrOverflow,
+		"word":        jsonic.ErrInvalidType,
+		"array":       jsonic.ErrInvalidType,
+		"object":      jsonic.ErrInvalidType,
+		"missing":     jsonic.ErrNoDataFound,
+	} {
+		_, err := j.AsInt64(path)
+		assert.True(t, errors.Is(err, expected), path)
+	}
+
+	var e *jsonic.PathError
+	_, err = j.AsInt("array")
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, jsonic.KindArray, e.Actual)
+}
+
+func TestAsFloat64(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test11.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, expected := range map[string]float64{
+		"float":       4.5,
+		"floatString": 4.5,
+		"intString":   42,
+		"true":        1,
+		"null":        0,
+	} {
+		f, err := j.AsFloat64(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, f, path)
+	}
+	_, err = j.AsFloat64("word")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+
+	// without the option, the numbers are float64 as well
+	f, err := jsonic.MustCompile("float").AsInt(j)
+	assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+	assert.Equal(t, 0, f)
+}
+
+func TestAsBool(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test11.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, expected := range map[string]bool{
+		"true":  true,
+		"false": false,
+		"null":  false,
+		"yes":   true,
+		"off":   false,
+		"one":   true,
+	} {
+		b, err := j.AsBool(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, b, path)
+	}
+	for _, path := range []string{"two", "word", "array"} {
+		_, err := j.AsBool(path)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidType), path)
+	}
+}
+
+func TestAsString(t *testing.T) {
+	j, err := jsonic.NewWithOptions(readFromFile("test_data/test11.json", t), jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, expected := range map[string]string{
+		"word":  "naruto",
+		"int":   "42",
+		"float": "4.5",
+		"big":   "9223372036854775807",
+		"true":  "true",
+		"null":  "",
+	} {
+		s, err := j.AsString(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, s, path)
+	}
+	_, err = j.AsString("object")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+
+	j, err = jsonic.New([]byte(`[1e21, 100, 0.000001]`))
+	assert.NoError(t, err)
+	for path, expected := range map[string]string{"[0]": "1e+21", "[1]": "100", "[2]": "0.000001"} {
+		s, err := j.AsString(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, s, path)
+	}
+}
+
+func TestAsDuration(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test11.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, expected := range map[string]time.Duration{
+		"duration":      90 * time.Minute,
+		"seconds":       1500 * time.Millisecond,
+		"secondsString": 90 * time.Second,
+		"null":          0,
+	} {
+		d, err := j.AsDuration(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, d, path)
+	}
+	for _, path := range []string{"true", "word", "object"} {
+		_, err := j.AsDuration(path)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidType), path)
+	}
+}
+
+func TestAsTime(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test11.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	tm, err := j.AsTime("time")
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC).Equal(tm))
+	tm, err = j.AsTime("unix")
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2021, 3, 4, 5, 6, 7, 5e8, time.UTC).Equal(tm))
+	tm, err = j.AsTime("null")
+	assert.NoError(t, err)
+	assert.True(t, tm.IsZero())
+
+	// layouts
+	_, err = j.AsTime("date")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	tm, err = j.AsTime("date", time.RFC3339, "2006-01-02")
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC).Equal(tm))
+	tm, err = jsonic.MustCompile("time").AsTime(j, "2006-01-02")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	assert.True(t, tm.IsZero())
+
+	_, err = j.AsTime("false")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+}
@@ -0,0 +1,306 @@
+package jsonic
+
+import "sync"
+
+// maxCompiledPaths is the number of the paths cached after being parsed, as the paths
+// provided to the getters can be built at runtime, and so cannot be cached without a limit.
+const maxCompiledPaths = 1024
+
+var compiledPaths = struct {
+	sync.Mutex
+	cache map[string]*Path
+}{cache: make(map[string]*Path)}
+
+// Path is the compiled path, which can be used to query any number of json trees
+// without parsing the path again.
+type Path struct {
+	path     string
+	elements []pathElement
+	err      error
+}
+
+// Compile parses the path, so that it can be used any number of times.
+//
+// The path is the same as the one accepted by Child, and it returns
+// an error in case the path is not valid.
+func Compile(path string) (*Path, error) {
+	p := compilePath(path)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p, nil
+}
+
+// MustCompile is like Compile, but panics in case the path is not valid.
+// It is useful to initialise the global variables holding the compiled paths.
+func MustCompile(path string) *Path {
+	p, err := Compile(path)
+	if err != nil {
+		panic("jsonic: Compile(" + path + "): " + err.Error())
+	}
+	return p
+}
+
+// String returns the path it was compiled from.
+func (p *Path) String() string {
+	return p.path
+}
+
+// ChildPath returns the json tree at the compiled path.
+func (j *Jsonic) ChildPath(p *Path) (*Jsonic, error) {
+	return p.Child(j)
+}
+
+// GetPath is used to get the data at the compiled path.
+func (j *Jsonic) GetPath(p *Path) (interface{}, error) {
+	return p.Get(j)
+}
+
+// Child returns the json tree at this path in the json tree provided.
+//
+// In case the path cannot be resolved, the error returned is a *PathError.
+func (p *Path) Child(j *Jsonic) (*Jsonic, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.path == dot || p.path == empty {
+		return j.getDotOrEmptyChild(p.path), nil
+	}
+	child, err := j.child(p.elements)
+	if err != nil {
+		segment, at := j.failure(p.elements, 0)
+		if segment >= len(p.elements) {
+			segment = len(p.elements) - 1
+		}
+		return nil, p.errorAt(segment, p.elements[segment].expects(), at.Kind(), err)
+	}
+	return child, nil
+}
+
+// Get is used to get the data at this path in the json tree provided.
+func (p *Path) Get(j *Jsonic) (interface{}, error) {
+	child, err := p.Child(j)
+	if err != nil {
+		return nil, err
+	}
+	return child.value(), nil
+}
+
+// GetTyped is used to get the data at this path in the value provided,
+// in the same way as it is done by Jsonic.GetTyped.
+func (p *Path) GetTyped(j *Jsonic, val interface{}) error {
+	child, err := p.Child(j)
+	if err != nil {
+		return err
+	}
+	return child.parseInto(val)
+}
+
+// GetInt is used to get the integer at this path.
+//
+// It returns an error in case the number does not fit in an int, and
+// ErrPrecision in case it has a fractional part, instead of truncating it.
+func (p *Path) GetInt(j *Jsonic) (int, error) {
+	val, err := p.Get(j)
+	i, err := intOf(val, err)
+	return i, p.typeError(err, val, KindNumber)
+}
+
+// GetInt64 is used to get the integer at this path.
+//
+// It returns an error in case the number does not fit in an int64, and
+// ErrPrecision in case it has a fractional part, instead of truncating it.
+// Note that the integers beyond 2^53 can only be retrieved exactly
+// when the numbers are parsed using the UseNumber option.
+func (p *Path) GetInt64(j *Jsonic) (int64, error) {
+	val, err := p.Get(j)
+	i, err := int64Of(val, err)
+	return i, p.typeError(err, val, KindNumber)
+}
+
+// GetFloat is used to get the floating point number at this path.
+//
+// It returns an error in case the number does not fit in a float32.
+func (p *Path) GetFloat(j *Jsonic) (float32, error) {
+	val, err := p.Get(j)
+	f, err := floatOf(val, err)
+	return f, p.typeError(err, val, KindNumber)
+}
+
+// GetFloat64 is used to get the floating point number at this path.
+//
+// It returns an error in case the number does not fit in a float64.
+func (p *Path) GetFloat64(j *Jsonic) (float64, error) {
+	val, err := p.Get(j)
+	f, err := float64Of(val, err)
+	return f, p.typeError(err, val, KindNumber)
+}
+
+// GetBool is used to get the boolean at this path.
+func (p *Path) GetBool(j *Jsonic) (bool, error) {
+	val, err := p.Get(j)
+	b, err := boolOf(val, err)
+	return b, p.typeError(err, val, KindBool)
+}
+
+// GetString is used to get the string at this path.
+func (p *Path) GetString(j *Jsonic) (string, error) {
+	val, err := p.Get(j)
+	s, err := stringOf(val, err)
+	return s, p.typeError(err, val, KindString)
+}
+
+// GetArray is used to get the data array at this path.
+func (p *Path) GetArray(j *Jsonic) ([]interface{}, error) {
+	val, err := p.Get(j)
+	v, err := arrayOf(val, err)
+	return v, p.typeError(err, val, KindArray)
+}
+
+// GetIntArray is used to get the integer array at this path.
+func (p *Path) GetIntArray(j *Jsonic) ([]int, error) {
+	val, err := p.Get(j)
+	v, err := intArrayOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindArray)
+}
+
+// GetInt64Array is used to get the 64-bit integer array at this path.
+func (p *Path) GetInt64Array(j *Jsonic) ([]int64, error) {
+	val, err := p.Get(j)
+	v, err := int64ArrayOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindArray)
+}
+
+// GetFloatArray is used to get the floating point number array at this path.
+func (p *Path) GetFloatArray(j *Jsonic) ([]float32, error) {
+	val, err := p.Get(j)
+	v, err := floatArrayOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindArray)
+}
+
+// GetFloat64Array is used to get the 64-bit floating point number array at this path.
+func (p *Path) GetFloat64Array(j *Jsonic) ([]float64, error) {
+	val, err := p.Get(j)
+	v, err := float64ArrayOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindArray)
+}
+
+// GetBoolArray is used to get the boolean array at this path.
+func (p *Path) GetBoolArray(j *Jsonic) ([]bool, error) {
+	val, err := p.Get(j)
+	v, err := boolArrayOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindArray)
+}
+
+// GetStringArray is used to get the string array at this path.
+func (p *Path) GetStringArray(j *Jsonic) ([]string, error) {
+	val, err := p.Get(j)
+	v, err := stringArrayOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindArray)
+}
+
+// GetMap is used to get the data map at this path.
+func (p *Path) GetMap(j *Jsonic) (map[string]interface{}, error) {
+	val, err := p.Get(j)
+	v, err := mapOf(val, err)
+	return v, p.typeError(err, val, KindObject)
+}
+
+// GetIntMap is used to get the integer map at this path.
+func (p *Path) GetIntMap(j *Jsonic) (map[string]int, error) {
+	val, err := p.Get(j)
+	v, err := intMapOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindObject)
+}
+
+// GetInt64Map is used to get the 64-bit integer map at this path.
+func (p *Path) GetInt64Map(j *Jsonic) (map[string]int64, error) {
+	val, err := p.Get(j)
+	v, err := int64MapOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindObject)
+}
+
+// GetFloatMap is used to get the floating point number map at this path.
+func (p *Path) GetFloatMap(j *Jsonic) (map[string]float32, error) {
+	val, err := p.Get(j)
+	v, err := floatMapOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindObject)
+}
+
+// GetFloat64Map is used to get the 64-bit floating point number map at this path.
+func (p *Path) GetFloat64Map(j *Jsonic) (map[string]float64, error) {
+	val, err := p.Get(j)
+	v, err := float64MapOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindObject)
+}
+
+// GetBoolMap is used to get the boolean map at this path.
+func (p *Path) GetBoolMap(j *Jsonic) (map[string]bool, error) {
+	val, err := p.Get(j)
+	v, err := boolMapOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindObject)
+}
+
+// GetStringMap is used to get the string map at this path.
+func (p *Path) GetStringMap(j *Jsonic) (map[string]string, error) {
+	val, err := p.Get(j)
+	v, err := stringMapOf(val, err, j.opts)
+	return v, p.typeError(err, val, KindObject)
+}
+
+// compilePath parses the path, and in case it is not valid,
+// the error is kept to be returned while using it.
+//
+// The valid paths are cached, so that the getters taking the path do not parse it every time.
+func compilePath(path string) *Path {
+	compiledPaths.Lock()
+	cached, ok := compiledPaths.cache[path]
+	compiledPaths.Unlock()
+	if ok {
+		return cached
+	}
+	p := &Path{path: path}
+	if path == dot || path == empty {
+		// resolved specially, so there is nothing to parse
+		return p
+	}
+	elements, err := parsePathOrPointer(path)
+	if err != nil {
+		p.err = p.errorAt(-1, 0, 0, err)
+		return p
+	}
+	p.elements = elements
+	compiledPaths.Lock()
+	if len(compiledPaths.cache) >= maxCompiledPaths {
+		// the cache is started over, instead of keeping track of the ones used recently
+		compiledPaths.cache = make(map[string]*Path)
+	}
+	compiledPaths.cache[path] = p
+	compiledPaths.Unlock()
+	return p
+}
+
+// typeError returns the error for the data of the unexpected kind at this path,
+// which is the same as the one provided in case it is already a *PathError.
+func (p *Path) typeError(err error, data interface{}, expected Kind) error {
+	if err == nil {
+		return nil
+	}
+	switch e := err.(type) {
+	case *PathError:
+		return e
+	case *elementError:
+		pathErr := p.errorAt(len(p.elements)-1, e.expected, e.actual, e.err)
+		pathErr.Element = e.element
+		return pathErr
+	}
+	return p.errorAt(len(p.elements)-1, expected, kindOf(data), err)
+}
+
+func (p *Path) errorAt(segment int, expected, actual Kind, err error) *PathError {
+	e := &PathError{Path: p.path, Segment: segment, Expected: expected, Actual: actual, Err: err}
+	if segment >= 0 {
+		e.key = p.elements[segment].key
+	}
+	return e
+}
@@ -0,0 +1,160 @@
+package jsonic_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for _, path := range []string{"a.x.y", "a.arr[0].c.d.e", "/a/arr/0/c.d/e", "a.x", ".", ""} {
+		p, err := jsonic.Compile(path)
+		assert.NoError(t, err, path)
+		assert.NotNil(t, p, path)
+		assert.Equal(t, path, p.String())
+
+		// same as the path
+		expected, err := j.Child(path)
+		assert.NoError(t, err, path)
+		c, err := p.Child(j)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, c, path)
+		c, err = j.ChildPath(p)
+		assert.NoError(t, err, path)
+		assert.Equal(t, expected, c, path)
+
+		v, err := p.Get(j)
+		assert.NoError(t, err, path)
+		w, err := j.GetPath(p)
+		assert.NoError(t, err, path)
+		assert.Equal(t, v, w, path)
+	}
+
+	// the same path used with multiple json trees
+	p := jsonic.MustCompile("a.arr[0].a")
+	for i := 0; i < 3; i++ {
+		j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+		assert.NoError(t, err)
+		s, err := p.GetString(j)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", s)
+	}
+
+	// not found
+	p = jsonic.MustCompile("a.m")
+	_, err = p.Get(j)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+}
+
+func TestCompileError(t *testing.T) {
+	for _, path := range []string{`a["b`, `a\`, `a["b"]c`, "a[?(@.b ==)]", "/a~2"} {
+		p, err := jsonic.Compile(path)
+		assert.Nil(t, p, path)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidPath), path)
+		assert.Panics(t, func() { jsonic.MustCompile(path) }, path)
+	}
+}
+
+func TestCompileTyped(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test2.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i, err := jsonic.MustCompile("a").GetInt(j)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+	i64, err := jsonic.MustCompile("a").GetInt64(j)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), i64)
+	f, err := jsonic.MustCompile("b").GetFloat(j)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(2.2), f)
+	f64, err := jsonic.MustCompile("b").GetFloat64(j)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.2, f64)
+	b, err := jsonic.MustCompile("c").GetBool(j)
+	assert.NoError(t, err)
+	assert.True(t, b)
+	s, err := jsonic.MustCompile("d").GetString(j)
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", s)
+	_, err = jsonic.MustCompile("d").GetInt(j)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+
+	a, err := jsonic.MustCompile("e").GetArray(j)
+	assert.NoError(t, err)
+	assert.Len(t, a, 2)
+	ia, err := jsonic.MustCompile("e").GetIntArray(j)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ia)
+	i64a, err := jsonic.MustCompile("e").GetInt64Array(j)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, i64a)
+	fa, err := jsonic.MustCompile("f").GetFloatArray(j)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1.1, 2.2}, fa)
+	f64a, err := jsonic.MustCompile("f").GetFloat64Array(j)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1.1, 2.2}, f64a)
+	ba, err := jsonic.MustCompile("g").GetBoolArray(j)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, ba)
+	sa, err := jsonic.MustCompile("h").GetStringArray(j)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"naruto", "boruto"}, sa)
+
+	m, err := jsonic.MustCompile("i").GetMap(j)
+	assert.NoError(t, err)
+	assert.Len(t, m, 1)
+	im, err := jsonic.MustCompile("i").GetIntMap(j)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"naruto": 1}, im)
+	i64m, err := jsonic.MustCompile("i").GetInt64Map(j)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"naruto": 1}, i64m)
+	fm, err := jsonic.MustCompile("j").GetFloatMap(j)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float32{"naruto": 1.1}, fm)
+	f64m, err := jsonic.MustCompile("j").GetFloat64Map(j)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"naruto": 1.1}, f64m)
+	bm, err := jsonic.MustCompile("k").GetBoolMap(j)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"naruto": true}, bm)
+	sm, err := jsonic.MustCompile("l").GetStringMap(j)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"naruto": "rocks"}, sm)
+
+	var v struct {
+		Naruto int `json:"naruto"`
+	}
+	err = jsonic.MustCompile("i").GetTyped(j, &v)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v.Naruto)
+}
+
+func TestCompileManyPaths(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": {"b.c": [1, 2, 3]}, "d": {"e": "f"}}`))
+	assert.NoError(t, err)
+
+	// more paths than the ones cached, each of them resolved twice
+	for n := 0; n < 2; n++ {
+		for i := 0; i < 3000; i++ {
+			v, err := j.GetInt(fmt.Sprintf(`a.b\.c[%d]`, i%3-3))
+			assert.NoError(t, err)
+			assert.Equal(t, i%3+1, v)
+			_, err = j.Get(fmt.Sprintf("d.e%d", i))
+			assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+		}
+	}
+	s, err := j.GetString(`["d"].e`)
+	assert.NoError(t, err)
+	assert.Equal(t, "f", s)
+}
@@ -6,4 +6,18 @@ const (
 	space        = " "
 	openBracket  = "["
 	closeBracket = "]"
+	wildcard     = "*"
+	descent      = ".."
+	slash        = "/"
+)
+
+// natures of the path elements
+const (
+	natureKey = iota
+	natureIndex
+	natureWildcard
+	natureSlice
+	natureDescent
+	natureToken
+	natureFilter
 )
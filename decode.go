@@ -0,0 +1,621 @@
+package jsonic
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	unmarshalerType     = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	numberType          = reflect.TypeOf(json.Number(empty))
+)
+
+// field is a field of the struct, which the json data can be decoded into.
+type field struct {
+	name   string
+	tagged bool
+	index  []int
+	typ    reflect.Type
+	quoted bool
+}
+
+// fieldsCache keeps the fields of each of the struct types decoded.
+var fieldsCache sync.Map
+
+// decoder sets the json data directly in the go values, without encoding it again,
+// following the same rules as the encoding/json package.
+type decoder struct {
+	// the names of the fields leading to the value being decoded
+	fields []string
+	// the struct holding the field being decoded
+	structName string
+	err        error
+}
+
+// decode sets the json data in the value provided, which should be a non-nil pointer.
+//
+// Same as the encoding/json package, the decoding continues in case some data
+// does not match the type, and the first of such errors is returned.
+func decode(data interface{}, val interface{}) error {
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return &json.InvalidUnmarshalError{Type: reflect.TypeOf(val)}
+	}
+	d := &decoder{}
+	d.value(data, v.Elem())
+	return d.err
+}
+
+func (d *decoder) value(data interface{}, v reflect.Value) {
+	if data == nil {
+		switch v.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+	}
+	for {
+		if v.Kind() != reflect.Ptr && v.CanAddr() && d.unmarshal(data, v.Addr()) {
+			return
+		}
+		if v.Kind() != reflect.Ptr {
+			break
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if data == nil {
+		// nothing to do for the others
+		return
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			d.typeError(data, v.Type())
+			return
+		}
+		v.Set(reflect.ValueOf(copyData(data)))
+	case reflect.Bool:
+		b, ok := data.(bool)
+		if !ok {
+			d.typeError(data, v.Type())
+			return
+		}
+		v.SetBool(b)
+	case reflect.String:
+		d.string(data, v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := exactInt64(data)
+		if !ok || v.OverflowInt(i) {
+			d.typeError(data, v.Type())
+			return
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, ok := exactUint64(data)
+		if !ok || v.OverflowUint(u) {
+			d.typeError(data, v.Type())
+			return
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(data, v.Type().Bits())
+		if err != nil {
+			d.typeError(data, v.Type())
+			return
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		d.slice(data, v)
+	case reflect.Array:
+		d.array(data, v)
+	case reflect.Map:
+		d.object(data, v)
+	case reflect.Struct:
+		d.fieldsOf(data, v)
+	default:
+		d.typeError(data, v.Type())
+	}
+}
+
+// unmarshal decodes the data using the json.Unmarshaler or the encoding.TextUnmarshaler
+// implemented by the pointer, reporting whether it does implement any of them.
+func (d *decoder) unmarshal(data interface{}, p reflect.Value) bool {
+	if p.Type().Implements(unmarshalerType) {
+		e := newEncoder(nil)
+		err := e.encode(data, 0)
+		if err == nil {
+			err = p.Interface().(json.Unmarshaler).UnmarshalJSON(e.buf)
+		}
+		d.save(err)
+		return true
+	}
+	if p.Type().Implements(textUnmarshalerType) {
+		switch v := data.(type) {
+		case nil:
+			// same as the encoding/json package, null is ignored
+		case string:
+			d.save(p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v)))
+		default:
+			d.typeError(data, p.Type().Elem())
+		}
+		return true
+	}
+	return false
+}
+
+func (d *decoder) string(data interface{}, v reflect.Value) {
+	if v.Type() == numberType {
+		switch n := data.(type) {
+		case json.Number:
+			v.SetString(string(n))
+			return
+		case float64:
+			v.SetString(strconv.FormatFloat(n, 'g', -1, 64))
+			return
+		}
+	}
+	s, ok := data.(string)
+	if !ok {
+		d.typeError(data, v.Type())
+		return
+	}
+	v.SetString(s)
+}
+
+func (d *decoder) slice(data interface{}, v reflect.Value) {
+	if s, ok := data.(string); ok && v.Type().Elem().Kind() == reflect.Uint8 {
+		// the bytes are encoded as base64 strings
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			d.save(err)
+			return
+		}
+		v.SetBytes(b)
+		return
+	}
+	array, ok := data.([]interface{})
+	if !ok {
+		d.typeError(data, v.Type())
+		return
+	}
+	s := reflect.MakeSlice(v.Type(), len(array), len(array))
+	for i, element := range array {
+		d.value(element, s.Index(i))
+	}
+	v.Set(s)
+}
+
+func (d *decoder) array(data interface{}, v reflect.Value) {
+	array, ok := data.([]interface{})
+	if !ok {
+		d.typeError(data, v.Type())
+		return
+	}
+	for i := 0; i < v.Len(); i++ {
+		if i < len(array) {
+			d.value(array[i], v.Index(i))
+			continue
+		}
+		// the remaining elements are set to the zero value, and the extra ones are ignored
+		v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+	}
+}
+
+func (d *decoder) object(data interface{}, v reflect.Value) {
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		d.typeError(data, v.Type())
+		return
+	}
+	t := v.Type()
+	keyType := t.Key()
+	switch {
+	case keyType.Kind() == reflect.String, reflect.PtrTo(keyType).Implements(textUnmarshalerType):
+	default:
+		switch keyType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			d.typeError(data, t)
+			return
+		}
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMapWithSize(t, len(object)))
+	}
+	for k, element := range object {
+		key, ok := d.key(k, keyType)
+		if !ok {
+			continue
+		}
+		e := reflect.New(t.Elem()).Elem()
+		d.value(element, e)
+		v.SetMapIndex(key, e)
+	}
+}
+
+// key converts the key of the object to the type of the keys of the map.
+func (d *decoder) key(k string, t reflect.Type) (reflect.Value, bool) {
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) && t.Kind() != reflect.String {
+		key := reflect.New(t)
+		err := key.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(k))
+		if err != nil {
+			d.save(err)
+			return reflect.Value{}, false
+		}
+		return key.Elem(), true
+	}
+	key := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		key.SetString(k)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(k, 10, 64)
+		if err != nil || key.OverflowInt(i) {
+			d.typeError(k, t)
+			return reflect.Value{}, false
+		}
+		key.SetInt(i)
+	default:
+		u, err := strconv.ParseUint(k, 10, 64)
+		if err != nil || key.OverflowUint(u) {
+			d.typeError(k, t)
+			return reflect.Value{}, false
+		}
+		key.SetUint(u)
+	}
+	return key, true
+}
+
+func (d *decoder) fieldsOf(data interface{}, v reflect.Value) {
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		d.typeError(data, v.Type())
+		return
+	}
+	fields := cachedFields(v.Type())
+	structName := d.structName
+	d.structName = v.Type().Name()
+	// the fields are decoded in their order, so that the error returned is always the same
+	exact := 0
+	for i := range fields {
+		if element, ok := object[fields[i].name]; ok {
+			exact++
+			d.field(element, v, &fields[i])
+		}
+	}
+	if exact < len(object) {
+		// the keys matching the fields only when the case is ignored
+		var keys []string
+		for k := range object {
+			if f := findField(fields, k); f != nil && f.name != k {
+				if _, ok := object[f.name]; !ok {
+					keys = append(keys, k)
+				}
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			d.field(object[k], v, findField(fields, k))
+		}
+	}
+	d.structName = structName
+}
+
+func (d *decoder) field(data interface{}, v reflect.Value, f *field) {
+	fv, ok := d.fieldValue(v, f)
+	if !ok {
+		return
+	}
+	d.fields = append(d.fields, f.name)
+	if f.quoted {
+		d.quoted(data, fv)
+	} else {
+		d.value(data, fv)
+	}
+	d.fields = d.fields[:len(d.fields)-1]
+}
+
+// fieldValue returns the value of the field, allocating the embedded structs on the way.
+func (d *decoder) fieldValue(v reflect.Value, f *field) (reflect.Value, bool) {
+	for i, index := range f.index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					d.save(errors.New("json: cannot set embedded pointer to unexported struct: " + v.Type().Elem().String()))
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(index)
+	}
+	// an unexported embedded struct with a name
+	return v, v.CanSet()
+}
+
+// quoted decodes the data of the field with the string option, where the json string holds the value.
+func (d *decoder) quoted(data interface{}, v reflect.Value) {
+	s, ok := data.(string)
+	if !ok {
+		if data != nil {
+			d.save(errors.New("json: invalid use of ,string struct tag, trying to unmarshal unquoted value into " +
+				v.Type().String()))
+		}
+		return
+	}
+	// the numbers are kept as they are, so that the large integers are not rounded off
+	inner, err := (&options{useNumber: true}).unmarshal([]byte(s))
+	if err == nil {
+		switch inner.(type) {
+		case nil, bool, json.Number, string:
+		default:
+			err = errors.New("not a literal")
+		}
+	}
+	if err != nil {
+		d.save(errors.New("json: invalid use of ,string struct tag, trying to unmarshal " + strconv.Quote(s) +
+			" into " + v.Type().String()))
+		return
+	}
+	d.value(inner, v)
+}
+
+func (d *decoder) typeError(data interface{}, t reflect.Type) {
+	value := kindOf(data).String()
+	switch v := data.(type) {
+	case float64:
+		value += " " + strconv.FormatFloat(v, 'g', -1, 64)
+	case json.Number:
+		value += " " + string(v)
+	}
+	err := &json.UnmarshalTypeError{Value: value, Type: t}
+	if len(d.fields) > 0 {
+		err.Struct = d.structName
+		err.Field = strings.Join(d.fields, dot)
+	}
+	d.save(err)
+}
+
+// save keeps the first of the errors.
+func (d *decoder) save(err error) {
+	if d.err == nil && err != nil {
+		d.err = err
+	}
+}
+
+// exactInt64 returns the json number as an integer, only if it does not have any fractional part.
+func exactInt64(data interface{}) (int64, bool) {
+	switch v := data.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		i, err := floatToInt64(v, 64)
+		return i, err == nil
+	case json.Number:
+		i, err := strconv.ParseInt(string(v), 10, 64)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// exactUint64 returns the json number as an unsigned integer, only if it does not have any fractional part.
+func exactUint64(data interface{}) (uint64, bool) {
+	switch v := data.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		u, err := floatToUint64(v, 64)
+		return u, err == nil
+	case json.Number:
+		u, err := strconv.ParseUint(string(v), 10, 64)
+		return u, err == nil
+	}
+	return 0, false
+}
+
+// copyData returns a deep copy of the json data, so that the json tree is not modified using it.
+func copyData(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = copyData(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = copyData(e)
+		}
+		return a
+	}
+	return data
+}
+
+// findField returns the field with the name, or the first one with the same name ignoring the case.
+func findField(fields []field, name string) *field {
+	var folded *field
+	for i := range fields {
+		f := &fields[i]
+		if f.name == name {
+			return f
+		}
+		if folded == nil && strings.EqualFold(f.name, name) {
+			folded = f
+		}
+	}
+	return folded
+}
+
+func cachedFields(t reflect.Type) []field {
+	if fields, ok := fieldsCache.Load(t); ok {
+		return fields.([]field)
+	}
+	fields, _ := fieldsCache.LoadOrStore(t, typeFields(t))
+	return fields.([]field)
+}
+
+// typeFields returns the fields of the struct which the json data can be decoded into,
+// including the ones promoted from the embedded structs, as per the visibility rules of go.
+func typeFields(t reflect.Type) []field {
+	var fields []field
+	current := []field{}
+	next := []field{{typ: t}}
+	count := map[reflect.Type]int{}
+	nextCount := map[reflect.Type]int{}
+	visited := map[reflect.Type]bool{}
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+		for _, f := range current {
+			if visited[f.typ] {
+				continue
+			}
+			visited[f.typ] = true
+			for i := 0; i < f.typ.NumField(); i++ {
+				sf := f.typ.Field(i)
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+					// the exported fields of the unexported embedded structs are still promoted
+				} else if !sf.IsExported() {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+				ft := sf.Type
+				if ft.Name() == empty && ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if name != empty || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					tagged := name != empty
+					if name == empty {
+						name = sf.Name
+					}
+					fields = append(fields, field{
+						name:   name,
+						tagged: tagged,
+						index:  index,
+						typ:    ft,
+						quoted: hasOption(opts, "string") && isQuotable(ft),
+					})
+					if count[f.typ] > 1 {
+						// the same struct is embedded more than once at this level, so the field is
+						// added twice, making it ambiguous, and so dropped below
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, field{name: ft.Name(), index: index, typ: ft})
+				}
+			}
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		x := fields
+		if x[i].name != x[j].name {
+			return x[i].name < x[j].name
+		}
+		if len(x[i].index) != len(x[j].index) {
+			return len(x[i].index) < len(x[j].index)
+		}
+		if x[i].tagged != x[j].tagged {
+			return x[i].tagged
+		}
+		return lessIndex(x[i].index, x[j].index)
+	})
+	// keep only the dominant field for each of the names
+	out := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		name := fields[i].name
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != name {
+				break
+			}
+		}
+		if advance == 1 {
+			out = append(out, fields[i])
+			continue
+		}
+		if f, ok := dominantField(fields[i : i+advance]); ok {
+			out = append(out, f)
+		}
+	}
+	fields = out
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+	return fields
+}
+
+// dominantField returns the field which hides the others with the same name,
+// which are sorted by their depth and then by whether they are tagged.
+func dominantField(fields []field) (field, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return field{}, false
+	}
+	return fields[0], true
+}
+
+func lessIndex(x, y []int) bool {
+	for k, xik := range x {
+		if k >= len(y) {
+			return false
+		}
+		if xik != y[k] {
+			return xik < y[k]
+		}
+	}
+	return len(x) < len(y)
+}
+
+func hasOption(opts, option string) bool {
+	for opts != empty {
+		var name string
+		name, opts, _ = strings.Cut(opts, ",")
+		if name == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isQuotable reports whether the string option applies to the type.
+func isQuotable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
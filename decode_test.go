@@ -0,0 +1,216 @@
+package jsonic_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level " + string(text))
+	}
+	return nil
+}
+
+type Village struct {
+	Name   string `json:"name"`
+	Hokage *int   `json:"hokage,omitempty"`
+}
+
+type Ninja struct {
+	Team int    `json:"team"`
+	Rank string `json:"rank"`
+}
+
+type character struct {
+	Ninja
+	*Village    `json:"village"`
+	Name        string                 `json:"name,omitempty"`
+	Age         uint8                  // matched ignoring the case
+	Skills      []string               `json:"skills"`
+	Scores      map[int]int            `json:"scores"`
+	Ratings     []float32              `json:"ratings"`
+	Born        time.Time              `json:"born"`
+	Level       level                  `json:"level"`
+	Count       int64                  `json:"count,string"`
+	Data        []byte                 `json:"data"`
+	Extra       map[string]interface{} `json:"extra"`
+	Coordinates [2]int                 `json:"coordinates"`
+	Ignored     string                 `json:"-"`
+}
+
+func TestGetTypedDecode(t *testing.T) {
+	data := readFromFile("test_data/test13.json", t)
+	j, err := jsonic.New(data)
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	var c character
+	err = j.GetTyped(".", &c)
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", c.Name)
+	assert.Equal(t, uint8(17), c.Age)
+	assert.Equal(t, 7, c.Team)
+	assert.Equal(t, "genin", c.Rank)
+	assert.Equal(t, "konoha", c.Village.Name)
+	assert.Equal(t, 7, *c.Village.Hokage)
+	assert.Equal(t, []string{"rasengan", "shadow clone"}, c.Skills)
+	assert.Equal(t, map[int]int{1: 10, 2: 20}, c.Scores)
+	assert.Equal(t, []float32{4.5, 3, 0}, c.Ratings)
+	assert.Equal(t, time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC), c.Born)
+	assert.Equal(t, level(2), c.Level)
+	assert.Equal(t, int64(42), c.Count)
+	assert.Equal(t, []byte("hello"), c.Data)
+	assert.Equal(t, [2]int{1, 2}, c.Coordinates)
+	assert.Empty(t, c.Ignored)
+
+	// same as the standard library
+	var expected character
+	err = json.Unmarshal(data, &expected)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	// the json tree is not modified using the decoded data
+	c.Extra["a"].([]interface{})[0] = "changed"
+	a, err := j.Get("extra.a[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, a)
+}
+
+type hokage struct {
+	Name string
+}
+
+func (h *hokage) UnmarshalJSON(b []byte) error {
+	var names []string
+	err := json.Unmarshal(b, &names)
+	if err != nil {
+		return err
+	}
+	h.Name = strings.Join(names, " ")
+	return nil
+}
+
+func TestGetTypedUnmarshaler(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"hokage": ["minato", "namikaze"], "others": [["hashirama"], null]}`))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	var h hokage
+	err = j.GetTyped("hokage", &h)
+	assert.NoError(t, err)
+	assert.Equal(t, "minato namikaze", h.Name)
+
+	var others []*hokage
+	err = j.GetTyped("others", &others)
+	assert.NoError(t, err)
+	assert.Len(t, others, 2)
+	assert.Equal(t, "hashirama", others[0].Name)
+	assert.Nil(t, others[1])
+
+	err = j.GetTyped("hokage", h)
+	assert.Error(t, err)
+	var invalid *json.InvalidUnmarshalError
+	assert.True(t, errors.As(err, &invalid))
+}
+
+func TestGetTypedFieldErrors(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"name": 1, "Age": 300, "village": {"name": true}, "level": "medium", "count": 4}`))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	var c character
+	err = j.GetTyped(".", &c)
+	assert.Error(t, err)
+	var typeErr *json.UnmarshalTypeError
+	if assert.True(t, errors.As(err, &typeErr)) {
+		// the first of the errors is returned, and the others are still decoded
+		assert.Equal(t, "village.name", typeErr.Field)
+	}
+
+	var v Village
+	err = j.GetTyped("village", &v)
+	assert.Error(t, err)
+	assert.Equal(t, "json: cannot unmarshal bool into Go struct field Village.name of type string", err.Error())
+
+	var a struct {
+		Age uint8
+	}
+	err = j.GetTyped(".", &a)
+	assert.Error(t, err)
+	assert.Equal(t, "json: cannot unmarshal number 300 into Go struct field .Age of type uint8", err.Error())
+
+	var l struct {
+		Level level `json:"level"`
+	}
+	err = j.GetTyped(".", &l)
+	assert.Error(t, err)
+	assert.Equal(t, "unknown level medium", err.Error())
+
+	var n struct {
+		Count int `json:"count,string"`
+	}
+	err = j.GetTyped(".", &n)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "invalid use of ,string struct tag"))
+}
+
+func TestGetTypedUseNumber(t *testing.T) {
+	j, err := jsonic.NewWithOptions([]byte(`{"a": 9007199254740993, "b": "9007199254740993", "c": 1.5}`),
+		jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	var v struct {
+		A int64       `json:"a"`
+		B int64       `json:"b,string"`
+		C json.Number `json:"c"`
+	}
+	err = j.GetTyped(".", &v)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), v.A)
+	assert.Equal(t, int64(9007199254740993), v.B)
+	assert.Equal(t, json.Number("1.5"), v.C)
+
+	var i struct {
+		C int `json:"c"`
+	}
+	err = j.GetTyped(".", &i)
+	assert.Error(t, err)
+	assert.Equal(t, "json: cannot unmarshal number 1.5 into Go struct field .c of type int", err.Error())
+}
+
+func BenchmarkGetTyped(b *testing.B) {
+	j := newForBenchmark("test_data/test13.json", b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var c character
+		_ = j.GetTyped(".", &c)
+	}
+}
+
+func BenchmarkGetTypedRoundTrip(b *testing.B) {
+	j := newForBenchmark("test_data/test13.json", b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var c character
+		data, _ := j.Get(".")
+		bytes, _ := json.Marshal(data)
+		_ = json.Unmarshal(bytes, &c)
+	}
+}
@@ -0,0 +1,332 @@
+package jsonic
+
+// FallbackOnMismatch is used to make the utilities accepting a default value, like GetIntOr,
+// return the default value even when the data at the path does not match the expected type.
+//
+// By default, the default value is returned only when there is no data at the path,
+// or the data is null, and an error is returned for the data of any other type.
+func FallbackOnMismatch() Option {
+	return func(o *options) {
+		o.fallbackOnMismatch = true
+	}
+}
+
+// GetIntOr is used to get the data at the path specified like GetInt, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetIntOr(path string, def int) (int, error) {
+	return compilePath(path).GetIntOr(j, def)
+}
+
+// GetInt64Or is used to get the data at the path specified like GetInt64, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetInt64Or(path string, def int64) (int64, error) {
+	return compilePath(path).GetInt64Or(j, def)
+}
+
+// GetFloatOr is used to get the data at the path specified like GetFloat, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetFloatOr(path string, def float32) (float32, error) {
+	return compilePath(path).GetFloatOr(j, def)
+}
+
+// GetFloat64Or is used to get the data at the path specified like GetFloat64, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetFloat64Or(path string, def float64) (float64, error) {
+	return compilePath(path).GetFloat64Or(j, def)
+}
+
+// GetBoolOr is used to get the data at the path specified like GetBool, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetBoolOr(path string, def bool) (bool, error) {
+	return compilePath(path).GetBoolOr(j, def)
+}
+
+// GetStringOr is used to get the data at the path specified like GetString, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetStringOr(path string, def string) (string, error) {
+	return compilePath(path).GetStringOr(j, def)
+}
+
+// GetArrayOr is used to get the data at the path specified like GetArray, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetArrayOr(path string, def []interface{}) ([]interface{}, error) {
+	return compilePath(path).GetArrayOr(j, def)
+}
+
+// GetIntArrayOr is used to get the data at the path specified like GetIntArray, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetIntArrayOr(path string, def []int) ([]int, error) {
+	return compilePath(path).GetIntArrayOr(j, def)
+}
+
+// GetInt64ArrayOr is used to get the data at the path specified like GetInt64Array, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetInt64ArrayOr(path string, def []int64) ([]int64, error) {
+	return compilePath(path).GetInt64ArrayOr(j, def)
+}
+
+// GetFloatArrayOr is used to get the data at the path specified like GetFloatArray, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetFloatArrayOr(path string, def []float32) ([]float32, error) {
+	return compilePath(path).GetFloatArrayOr(j, def)
+}
+
+// GetFloat64ArrayOr is used to get the data at the path specified like GetFloat64Array, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetFloat64ArrayOr(path string, def []float64) ([]float64, error) {
+	return compilePath(path).GetFloat64ArrayOr(j, def)
+}
+
+// GetBoolArrayOr is used to get the data at the path specified like GetBoolArray, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetBoolArrayOr(path string, def []bool) ([]bool, error) {
+	return compilePath(path).GetBoolArrayOr(j, def)
+}
+
+// GetStringArrayOr is used to get the data at the path specified like GetStringArray, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetStringArrayOr(path string, def []string) ([]string, error) {
+	return compilePath(path).GetStringArrayOr(j, def)
+}
+
+// GetMapOr is used to get the data at the path specified like GetMap, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetMapOr(path string, def map[string]interface{}) (map[string]interface{}, error) {
+	return compilePath(path).GetMapOr(j, def)
+}
+
+// GetIntMapOr is used to get the data at the path specified like GetIntMap, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetIntMapOr(path string, def map[string]int) (map[string]int, error) {
+	return compilePath(path).GetIntMapOr(j, def)
+}
+
+// GetInt64MapOr is used to get the data at the path specified like GetInt64Map, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetInt64MapOr(path string, def map[string]int64) (map[string]int64, error) {
+	return compilePath(path).GetInt64MapOr(j, def)
+}
+
+// GetFloatMapOr is used to get the data at the path specified like GetFloatMap, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetFloatMapOr(path string, def map[string]float32) (map[string]float32, error) {
+	return compilePath(path).GetFloatMapOr(j, def)
+}
+
+// GetFloat64MapOr is used to get the data at the path specified like GetFloat64Map, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetFloat64MapOr(path string, def map[string]float64) (map[string]float64, error) {
+	return compilePath(path).GetFloat64MapOr(j, def)
+}
+
+// GetBoolMapOr is used to get the data at the path specified like GetBoolMap, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetBoolMapOr(path string, def map[string]bool) (map[string]bool, error) {
+	return compilePath(path).GetBoolMapOr(j, def)
+}
+
+// GetStringMapOr is used to get the data at the path specified like GetStringMap, returning
+// the default value provided in case there is no data at the path.
+func (j *Jsonic) GetStringMapOr(path string, def map[string]string) (map[string]string, error) {
+	return compilePath(path).GetStringMapOr(j, def)
+}
+
+// GetIntOr is used to get the data at this path like GetInt, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetIntOr(j *Jsonic, def int) (int, error) {
+	i, err := p.GetInt(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return i, err
+}
+
+// GetInt64Or is used to get the data at this path like GetInt64, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetInt64Or(j *Jsonic, def int64) (int64, error) {
+	i, err := p.GetInt64(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return i, err
+}
+
+// GetFloatOr is used to get the data at this path like GetFloat, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetFloatOr(j *Jsonic, def float32) (float32, error) {
+	f, err := p.GetFloat(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return f, err
+}
+
+// GetFloat64Or is used to get the data at this path like GetFloat64, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetFloat64Or(j *Jsonic, def float64) (float64, error) {
+	f, err := p.GetFloat64(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return f, err
+}
+
+// GetBoolOr is used to get the data at this path like GetBool, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetBoolOr(j *Jsonic, def bool) (bool, error) {
+	b, err := p.GetBool(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return b, err
+}
+
+// GetStringOr is used to get the data at this path like GetString, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetStringOr(j *Jsonic, def string) (string, error) {
+	s, err := p.GetString(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return s, err
+}
+
+// GetArrayOr is used to get the data at this path like GetArray, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetArrayOr(j *Jsonic, def []interface{}) ([]interface{}, error) {
+	v, err := p.GetArray(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetIntArrayOr is used to get the data at this path like GetIntArray, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetIntArrayOr(j *Jsonic, def []int) ([]int, error) {
+	v, err := p.GetIntArray(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetInt64ArrayOr is used to get the data at this path like GetInt64Array, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetInt64ArrayOr(j *Jsonic, def []int64) ([]int64, error) {
+	v, err := p.GetInt64Array(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetFloatArrayOr is used to get the data at this path like GetFloatArray, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetFloatArrayOr(j *Jsonic, def []float32) ([]float32, error) {
+	v, err := p.GetFloatArray(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetFloat64ArrayOr is used to get the data at this path like GetFloat64Array, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetFloat64ArrayOr(j *Jsonic, def []float64) ([]float64, error) {
+	v, err := p.GetFloat64Array(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetBoolArrayOr is used to get the data at this path like GetBoolArray, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetBoolArrayOr(j *Jsonic, def []bool) ([]bool, error) {
+	v, err := p.GetBoolArray(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetStringArrayOr is used to get the data at this path like GetStringArray, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetStringArrayOr(j *Jsonic, def []string) ([]string, error) {
+	v, err := p.GetStringArray(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetMapOr is used to get the data at this path like GetMap, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetMapOr(j *Jsonic, def map[string]interface{}) (map[string]interface{}, error) {
+	v, err := p.GetMap(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetIntMapOr is used to get the data at this path like GetIntMap, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetIntMapOr(j *Jsonic, def map[string]int) (map[string]int, error) {
+	v, err := p.GetIntMap(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetInt64MapOr is used to get the data at this path like GetInt64Map, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetInt64MapOr(j *Jsonic, def map[string]int64) (map[string]int64, error) {
+	v, err := p.GetInt64Map(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetFloatMapOr is used to get the data at this path like GetFloatMap, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetFloatMapOr(j *Jsonic, def map[string]float32) (map[string]float32, error) {
+	v, err := p.GetFloatMap(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetFloat64MapOr is used to get the data at this path like GetFloat64Map, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetFloat64MapOr(j *Jsonic, def map[string]float64) (map[string]float64, error) {
+	v, err := p.GetFloat64Map(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetBoolMapOr is used to get the data at this path like GetBoolMap, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetBoolMapOr(j *Jsonic, def map[string]bool) (map[string]bool, error) {
+	v, err := p.GetBoolMap(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
+
+// GetStringMapOr is used to get the data at this path like GetStringMap, returning
+// the default value provided in case there is no data at this path.
+func (p *Path) GetStringMapOr(j *Jsonic, def map[string]string) (map[string]string, error) {
+	v, err := p.GetStringMap(j)
+	if j.opts.fallback(err) {
+		return def, nil
+	}
+	return v, err
+}
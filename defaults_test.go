@@ -0,0 +1,134 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrMissing(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test2.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	// present
+	i, err := j.GetIntOr("a", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+	s, err := j.GetStringOr("d", "boruto")
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", s)
+
+	// missing
+	i, err = j.GetIntOr("x", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, i)
+	i64, err := j.GetInt64Or("x.y", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), i64)
+	f, err := j.GetFloatOr("e[5]", 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(1.5), f)
+	f64, err := j.GetFloat64Or("d.x", 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f64)
+	b, err := j.GetBoolOr("x", true)
+	assert.NoError(t, err)
+	assert.True(t, b)
+	s, err = j.GetStringOr("x", "boruto")
+	assert.NoError(t, err)
+	assert.Equal(t, "boruto", s)
+
+	a, err := j.GetArrayOr("x", []interface{}{1.0})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1.0}, a)
+	ia, err := j.GetIntArrayOr("x", []int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, ia)
+	i64a, err := j.GetInt64ArrayOr("x", []int64{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, i64a)
+	fa, err := j.GetFloatArrayOr("x", []float32{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1}, fa)
+	f64a, err := j.GetFloat64ArrayOr("x", []float64{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1}, f64a)
+	ba, err := j.GetBoolArrayOr("x", []bool{true})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true}, ba)
+	sa, err := j.GetStringArrayOr("x", []string{"a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, sa)
+
+	m, err := j.GetMapOr("x", map[string]interface{}{"a": 1.0})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1.0}, m)
+	im, err := j.GetIntMapOr("x", map[string]int{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1}, im)
+	i64m, err := j.GetInt64MapOr("x", map[string]int64{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a": 1}, i64m)
+	fm, err := j.GetFloatMapOr("x", map[string]float32{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float32{"a": 1}, fm)
+	f64m, err := j.GetFloat64MapOr("x", map[string]float64{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"a": 1}, f64m)
+	bm, err := j.GetBoolMapOr("x", map[string]bool{"a": true})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true}, bm)
+	sm, err := j.GetStringMapOr("x", map[string]string{"a": "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "b"}, sm)
+
+	// compiled
+	i, err = jsonic.MustCompile("x").GetIntOr(j, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, i)
+
+	// invalid path is never defaulted
+	_, err = j.GetIntOr(`x["`, 5)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+}
+
+func TestGetOrMismatch(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": "x", "b": null, "c": [1, "x"], "d": 1e300}`))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i, err := j.GetIntOr("a", 5)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	assert.Equal(t, 0, i)
+	f, err := j.GetFloatOr("d", 1.5)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Equal(t, float32(0), f)
+	// null is same as missing
+	i, err = j.GetIntOr("b", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, i)
+	ia, err := j.GetIntArrayOr("b", []int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, ia)
+
+	j, err = jsonic.NewWithOptions([]byte(`{"a": "x", "b": null, "c": [1, "x"], "d": 1e300}`),
+		jsonic.FallbackOnMismatch(), jsonic.StrictElements())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i, err = j.GetIntOr("a", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, i)
+	f, err = j.GetFloatOr("d", 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(1.5), f)
+	ia, err = j.GetIntArrayOr("c", []int{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, ia)
+	s, err := j.GetStringOr("c", "y")
+	assert.NoError(t, err)
+	assert.Equal(t, "y", s)
+}
@@ -0,0 +1,323 @@
+package jsonic
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind is the kind of the difference between the json trees.
+type ChangeKind int
+
+// kinds of the changes
+const (
+	// ChangeAdded is for the data present only in the other json tree.
+	ChangeAdded ChangeKind = iota + 1
+	// ChangeRemoved is for the data present only in the first json tree.
+	ChangeRemoved
+	// ChangeChanged is for the data of the same kind with a different value.
+	ChangeChanged
+	// ChangeTypeChanged is for the data of different kinds.
+	ChangeTypeChanged
+)
+
+// Change is a difference between the json trees.
+type Change struct {
+	// Path is the path of the data, like a.b[0] or a["b.c"], which is
+	// . for the root. It is in the first json tree, except when the data
+	// is added, in which case it is in the other json tree.
+	Path string
+	// Pointer is the json pointer of the data, in the same json tree as the path.
+	Pointer string
+	// Kind is the kind of the change.
+	Kind ChangeKind
+	// Old is the data in the first json tree, which is nil when the data is added.
+	Old interface{}
+	// New is the data in the other json tree, which is nil when the data is removed.
+	New interface{}
+}
+
+// DiffOption is used to configure the way the json trees are compared.
+type DiffOption func(*differ)
+
+type differ struct {
+	ignoreArrayOrder bool
+	ignoredPaths     []string
+	tolerance        float64
+	// the json pointers ignored in each of the json trees
+	ignoredA map[string]bool
+	ignoredB map[string]bool
+	changes  []Change
+}
+
+// location is the path and the json pointer of the data in a json tree.
+type location struct {
+	path    string
+	pointer string
+}
+
+// IgnoreArrayOrder is used to compare the arrays irrespective of the order of their elements.
+//
+// Each of the elements of the first array is matched with an element of the other array
+// without any differences, and the ones left are reported as removed or added.
+func IgnoreArrayOrder() DiffOption {
+	return func(d *differ) {
+		d.ignoreArrayOrder = true
+	}
+}
+
+// IgnorePaths is used to leave out the data at the paths from the comparison.
+//
+// The paths are the same as the ones accepted by Query, and they are resolved on both the json trees.
+// The paths which are not valid do not match anything.
+func IgnorePaths(paths ...string) DiffOption {
+	return func(d *differ) {
+		d.ignoredPaths = append(d.ignoredPaths, paths...)
+	}
+}
+
+// NumericTolerance is used to consider the numbers differing by at most the tolerance as equal.
+func NumericTolerance(tolerance float64) DiffOption {
+	return func(d *differ) {
+		d.tolerance = tolerance
+	}
+}
+
+// Diff returns the differences between the json trees, as per the options provided.
+//
+// The objects are compared key by key, with the keys taken in the sorted order,
+// so the order of the keys in the json data is never significant, and the arrays
+// element by element. The numbers are compared numerically.
+// It returns an empty result in case there are no differences.
+func Diff(a, b *Jsonic, opts ...DiffOption) []Change {
+	d := &differ{}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.ignoredA = ignoredPointers(a, d.ignoredPaths)
+	d.ignoredB = ignoredPointers(b, d.ignoredPaths)
+	d.diff(location{}, location{}, a.value(), b.value())
+	return d.changes
+}
+
+// String returns the name of the kind.
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeChanged:
+		return "changed"
+	case ChangeTypeChanged:
+		return "type-changed"
+	}
+	return "unknown"
+}
+
+// UnifiedDiff renders the changes like a unified diff, with the names of the json trees
+// compared in the header. Each of the changes is a hunk beginning with its path, followed
+// by the old value prefixed with - and the new value prefixed with +, encoded as compact json.
+//
+//	--- expected
+//	+++ actual
+//	@@ a.b @@
+//	-1
+//	+2
+//
+// It returns an empty string in case there are no changes.
+func UnifiedDiff(changes []Change, nameA, nameB string) string {
+	if len(changes) == 0 {
+		return empty
+	}
+	var b strings.Builder
+	b.WriteString("--- " + nameA + "\n")
+	b.WriteString("+++ " + nameB + "\n")
+	for _, c := range changes {
+		b.WriteString("@@ " + c.Path + " @@\n")
+		if c.Kind != ChangeAdded {
+			b.WriteString("-" + compactJSON(c.Old) + "\n")
+		}
+		if c.Kind != ChangeRemoved {
+			b.WriteString("+" + compactJSON(c.New) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func compactJSON(data interface{}) string {
+	e := newEncoder(nil)
+	// the json data can always be encoded
+	_ = e.encode(data, 0)
+	return string(e.buf)
+}
+
+// ignoredPointers returns the json pointers, relative to the json tree, of the data at the paths.
+func ignoredPointers(j *Jsonic, paths []string) map[string]bool {
+	ignored := make(map[string]bool)
+	prefix := j.Pointer()
+	for _, path := range paths {
+		results, err := j.Query(path)
+		if err != nil {
+			continue
+		}
+		for _, result := range results {
+			ignored[strings.TrimPrefix(result.Pointer(), prefix)] = true
+		}
+	}
+	return ignored
+}
+
+func (d *differ) diff(la, lb location, a, b interface{}) {
+	if d.ignoredA[la.pointer] || d.ignoredB[lb.pointer] {
+		return
+	}
+	switch x := a.(type) {
+	case map[string]interface{}:
+		if y, ok := b.(map[string]interface{}); ok {
+			d.diffObjects(la, lb, x, y)
+			return
+		}
+	case []interface{}:
+		if y, ok := b.([]interface{}); ok {
+			if d.ignoreArrayOrder {
+				d.diffUnorderedArrays(la, lb, x, y)
+			} else {
+				d.diffArrays(la, lb, x, y)
+			}
+			return
+		}
+	}
+	switch {
+	case kindOf(a) != kindOf(b):
+		d.change(ChangeTypeChanged, la, a, b)
+	case isNumber(a):
+		if !d.equalNumbers(a, b) {
+			d.change(ChangeChanged, la, a, b)
+		}
+	case !equalValues(a, b):
+		d.change(ChangeChanged, la, a, b)
+	}
+}
+
+func (d *differ) diffObjects(la, lb location, a, b map[string]interface{}) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		x, inA := a[k]
+		y, inB := b[k]
+		switch {
+		case !inB:
+			d.removed(la.key(k), x)
+		case !inA:
+			d.added(lb.key(k), y)
+		default:
+			d.diff(la.key(k), lb.key(k), x, y)
+		}
+	}
+}
+
+func (d *differ) diffArrays(la, lb location, a, b []interface{}) {
+	i := 0
+	for ; i < len(a) && i < len(b); i++ {
+		d.diff(la.index(i), lb.index(i), a[i], b[i])
+	}
+	for k := i; k < len(a); k++ {
+		d.removed(la.index(k), a[k])
+	}
+	for k := i; k < len(b); k++ {
+		d.added(lb.index(k), b[k])
+	}
+}
+
+func (d *differ) diffUnorderedArrays(la, lb location, a, b []interface{}) {
+	matched := make([]bool, len(b))
+	for i, x := range a {
+		found := false
+		for k, y := range b {
+			if !matched[k] && d.same(la.index(i), lb.index(k), x, y) {
+				matched[k] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			d.removed(la.index(i), x)
+		}
+	}
+	for k, y := range b {
+		if !matched[k] {
+			d.added(lb.index(k), y)
+		}
+	}
+}
+
+// same reports whether there are no differences between the data.
+func (d *differ) same(la, lb location, a, b interface{}) bool {
+	count := len(d.changes)
+	d.diff(la, lb, a, b)
+	same := len(d.changes) == count
+	d.changes = d.changes[:count]
+	return same
+}
+
+func (d *differ) equalNumbers(a, b interface{}) bool {
+	if d.tolerance == 0 {
+		return compareNumbers(a, b) == 0
+	}
+	return math.Abs(numberAsFloat64(a)-numberAsFloat64(b)) <= d.tolerance
+}
+
+func (d *differ) removed(l location, data interface{}) {
+	if !d.ignoredA[l.pointer] {
+		d.change(ChangeRemoved, l, data, nil)
+	}
+}
+
+func (d *differ) added(l location, data interface{}) {
+	if !d.ignoredB[l.pointer] {
+		d.change(ChangeAdded, l, nil, data)
+	}
+}
+
+func (d *differ) change(kind ChangeKind, l location, before, after interface{}) {
+	path := l.path
+	if path == empty {
+		path = dot
+	}
+	d.changes = append(d.changes, Change{Path: path, Pointer: l.pointer, Kind: kind, Old: before, New: after})
+}
+
+// key returns the location of the value of the key in the object at this location.
+func (l location) key(k string) location {
+	var path string
+	switch {
+	case !plainKey(k):
+		path = l.path + `["` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(k) + `"]`
+	case l.path == empty:
+		path = k
+	default:
+		path = l.path + dot + k
+	}
+	return location{path: path, pointer: l.pointer + slash + escapeToken(k)}
+}
+
+// index returns the location of the element at the index in the array at this location.
+func (l location) index(i int) location {
+	s := strconv.Itoa(i)
+	return location{path: l.path + openBracket + s + closeBracket, pointer: l.pointer + slash + s}
+}
+
+// plainKey reports whether the key can be used in the path as it is.
+func plainKey(k string) bool {
+	return k != empty && k != wildcard && !strings.ContainsAny(k, `.[]\"'`) && !strings.HasPrefix(k, slash)
+}
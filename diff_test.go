@@ -0,0 +1,154 @@
+package jsonic_test
+
+import (
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustNew(t *testing.T, data string) *jsonic.Jsonic {
+	j, err := jsonic.New([]byte(data))
+	assert.NoError(t, err)
+	return j
+}
+
+// assertResolved checks that the paths and the json pointers of the changes refer to their data.
+func assertResolved(t *testing.T, a, b *jsonic.Jsonic, changes []jsonic.Change) {
+	for _, c := range changes {
+		j, expected := a, c.Old
+		if c.Kind == jsonic.ChangeAdded {
+			j, expected = b, c.New
+		}
+		assert.Equal(t, expected, mustGet(t, j, c.Path), c.Path)
+		child, err := j.ChildPointer(c.Pointer)
+		assert.NoError(t, err)
+		actual, err := child.Get(".")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, c.Pointer)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := mustNew(t, `{"name": "naruto", "age": 17, "tags": ["a", "b", "c"], "team": {"id": 7, "size": 3},
+		"a.b": {"c~d": 1, "e/f": [1]}, "x": null, "arr": [[1], {"k": "v"}], "same": {"k": [1.0]}}`)
+	b := mustNew(t, `{"name": "boruto", "age": "17", "tags": ["a", "c"], "team": {"id": 7, "rank": "genin"},
+		"a.b": {"c~d": 2, "e/f": [1, 2]}, "x": false, "arr": [[1], {"k": "w"}], "same": {"k": [1]}, "new": {}}`)
+	changes := jsonic.Diff(a, b)
+	assert.Equal(t, []jsonic.Change{
+		{Path: `["a.b"].c~d`, Pointer: "/a.b/c~0d", Kind: jsonic.ChangeChanged, Old: 1.0, New: 2.0},
+		{Path: `["a.b"].e/f[1]`, Pointer: "/a.b/e~1f/1", Kind: jsonic.ChangeAdded, New: 2.0},
+		{Path: "age", Pointer: "/age", Kind: jsonic.ChangeTypeChanged, Old: 17.0, New: "17"},
+		{Path: "arr[1].k", Pointer: "/arr/1/k", Kind: jsonic.ChangeChanged, Old: "v", New: "w"},
+		{Path: "name", Pointer: "/name", Kind: jsonic.ChangeChanged, Old: "naruto", New: "boruto"},
+		{Path: "new", Pointer: "/new", Kind: jsonic.ChangeAdded, New: map[string]interface{}{}},
+		{Path: "tags[1]", Pointer: "/tags/1", Kind: jsonic.ChangeChanged, Old: "b", New: "c"},
+		{Path: "tags[2]", Pointer: "/tags/2", Kind: jsonic.ChangeRemoved, Old: "c"},
+		{Path: "team.rank", Pointer: "/team/rank", Kind: jsonic.ChangeAdded, New: "genin"},
+		{Path: "team.size", Pointer: "/team/size", Kind: jsonic.ChangeRemoved, Old: 3.0},
+		{Path: "x", Pointer: "/x", Kind: jsonic.ChangeTypeChanged, Old: nil, New: false},
+	}, changes)
+	assertResolved(t, a, b, changes)
+
+	assert.Empty(t, jsonic.Diff(a, a))
+	assert.Empty(t, jsonic.Diff(mustNew(t, `{"a": 1, "b": 2}`), mustNew(t, `{"b": 2.0, "a": 1}`)))
+}
+
+func TestDiffRoot(t *testing.T) {
+	a, b := mustNew(t, `[1]`), mustNew(t, `{"a": 1}`)
+	assert.Equal(t, []jsonic.Change{
+		{Path: ".", Pointer: "", Kind: jsonic.ChangeTypeChanged, Old: []interface{}{1.0}, New: map[string]interface{}{"a": 1.0}},
+	}, jsonic.Diff(a, b))
+	assert.Equal(t, []jsonic.Change{
+		{Path: "[0]", Pointer: "/0", Kind: jsonic.ChangeChanged, Old: 1.0, New: 2.0},
+	}, jsonic.Diff(a, mustNew(t, `[2]`)))
+}
+
+func TestDiffIgnoreArrayOrder(t *testing.T) {
+	a := mustNew(t, `{"ids": [1, 2, 3, 2], "items": [{"id": 1, "tags": ["x", "y"]}, {"id": 2}]}`)
+	b := mustNew(t, `{"ids": [2, 4, 1, 2], "items": [{"id": 2}, {"id": 1, "tags": ["y", "x"]}]}`)
+	changes := jsonic.Diff(a, b, jsonic.IgnoreArrayOrder())
+	assert.Equal(t, []jsonic.Change{
+		{Path: "ids[2]", Pointer: "/ids/2", Kind: jsonic.ChangeRemoved, Old: 3.0},
+		{Path: "ids[1]", Pointer: "/ids/1", Kind: jsonic.ChangeAdded, New: 4.0},
+	}, changes)
+	assertResolved(t, a, b, changes)
+
+	assert.Len(t, jsonic.Diff(a, b), 7)
+}
+
+func TestDiffIgnorePaths(t *testing.T) {
+	a := mustNew(t, `{"id": 1, "meta": {"at": "x", "by": "y"}, "items": [{"id": 1, "at": 1}, {"id": 2, "at": 2}]}`)
+	b := mustNew(t, `{"id": 2, "meta": {"at": "z"}, "items": [{"id": 1, "at": 3}, {"id": 3, "at": 4}], "at": 1}`)
+	changes := jsonic.Diff(a, b, jsonic.IgnorePaths("meta", "..at"), jsonic.IgnorePaths("a[", "/id"))
+	assert.Equal(t, []jsonic.Change{
+		{Path: "items[1].id", Pointer: "/items/1/id", Kind: jsonic.ChangeChanged, Old: 2.0, New: 3.0},
+	}, changes)
+
+	// the paths are resolved on the child as well
+	changes = jsonic.Diff(mustChild(t, a, "items"), mustChild(t, b, "items"), jsonic.IgnorePaths("[*].at"))
+	assert.Equal(t, []jsonic.Change{
+		{Path: "[1].id", Pointer: "/1/id", Kind: jsonic.ChangeChanged, Old: 2.0, New: 3.0},
+	}, changes)
+
+	// the elements are matched ignoring the paths
+	changes = jsonic.Diff(a, b, jsonic.IgnorePaths("items[*].at", "id", "meta", "at"), jsonic.IgnoreArrayOrder())
+	assert.Equal(t, []jsonic.Change{
+		{Path: "items[1]", Pointer: "/items/1", Kind: jsonic.ChangeRemoved,
+			Old: map[string]interface{}{"id": 2.0, "at": 2.0}},
+		{Path: "items[1]", Pointer: "/items/1", Kind: jsonic.ChangeAdded,
+			New: map[string]interface{}{"id": 3.0, "at": 4.0}},
+	}, changes)
+}
+
+func TestDiffNumericTolerance(t *testing.T) {
+	a := mustNew(t, `{"a": 1.0, "b": [2.5, 3], "c": 10}`)
+	b := mustNew(t, `{"a": 1.004, "b": [2.495, 3.2], "c": 10}`)
+	assert.Equal(t, []jsonic.Change{
+		{Path: "b[1]", Pointer: "/b/1", Kind: jsonic.ChangeChanged, Old: 3.0, New: 3.2},
+	}, jsonic.Diff(a, b, jsonic.NumericTolerance(0.01)))
+	assert.Len(t, jsonic.Diff(a, b), 3)
+
+	// the numbers are compared exactly without the tolerance
+	x, err := jsonic.NewWithOptions([]byte(`[9007199254740993, 1.0]`), jsonic.UseNumber())
+	assert.NoError(t, err)
+	y, err := jsonic.NewWithOptions([]byte(`[9007199254740992, 1]`), jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.Len(t, jsonic.Diff(x, y), 1)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := mustNew(t, `{"a": {"b": 1}, "c": [1, 2], "d": "x"}`)
+	b := mustNew(t, `{"a": {"b": 2}, "c": [1], "e": {"f": true}, "d": "x"}`)
+	assert.Equal(t, `--- expected
++++ actual
+@@ a.b @@
+-1
++2
+@@ c[1] @@
+-2
+@@ e @@
++{"f":true}
+`, jsonic.UnifiedDiff(jsonic.Diff(a, b), "expected", "actual"))
+	assert.Equal(t, "", jsonic.UnifiedDiff(jsonic.Diff(a, a), "expected", "actual"))
+}
+
+func TestChangeKind(t *testing.T) {
+	assert.Equal(t, "added", jsonic.ChangeAdded.String())
+	assert.Equal(t, "removed", jsonic.ChangeRemoved.String())
+	assert.Equal(t, "changed", jsonic.ChangeChanged.String())
+	assert.Equal(t, "type-changed", jsonic.ChangeTypeChanged.String())
+	assert.Equal(t, "unknown", jsonic.ChangeKind(0).String())
+}
+
+func TestDiffQuotedKeys(t *testing.T) {
+	a := mustNew(t, `{"a.b": 1, "c[0]": 1, "q\"\\": 1, "*": 1, "": 1, "/x": 1, "it's": 1, "a": {"b": 1}}`)
+	b := mustNew(t, `{"a.b": 2, "c[0]": 2, "q\"\\": 2, "*": 2, "": 2, "/x": 2, "it's": 2, "a": {"b": 2}}`)
+	changes := jsonic.Diff(a, b)
+	var paths []string
+	for _, c := range changes {
+		paths = append(paths, c.Path)
+	}
+	assert.Equal(t, []string{`[""]`, `["*"]`, `["/x"]`, `a.b`, `["a.b"]`, `["c[0]"]`, `["it's"]`, `["q\"\\"]`}, paths)
+	assertResolved(t, a, b, changes)
+}
@@ -0,0 +1,149 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElementsDefault(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test10.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	// zero value in the arrays
+	ia, err := j.GetIntArray("ints")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0, 3}, ia)
+	fa, err := j.GetFloat64Array("floats")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1.5, 0, 2.5}, fa)
+	ba, err := j.GetBoolArray("bools")
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, ba)
+	sa, err := j.GetStringArray("strings")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"naruto", "", "boruto"}, sa)
+
+	// skipped in the maps
+	im, err := j.GetIntMap("intMap")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1}, im)
+	sm, err := j.GetStringMap("stringMap")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "naruto"}, sm)
+
+	// overflow is always an error
+	_, err = j.GetFloatArray("big")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	var e *jsonic.PathError
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, "[1]", e.Element)
+}
+
+func TestElementsSkip(t *testing.T) {
+	j, err := jsonic.NewWithOptions(readFromFile("test_data/test10.json", t), jsonic.SkipElements())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	ia, err := j.GetIntArray("ints")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, ia)
+	i64a, err := j.GetInt64Array("ints")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 3}, i64a)
+	fa, err := j.GetFloatArray("floats")
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1.5, 2.5}, fa)
+	f64a, err := j.GetFloat64Array("floats")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1.5, 2.5}, f64a)
+	ba, err := j.GetBoolArray("bools")
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, ba)
+	sa, err := j.GetStringArray("strings")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"naruto", "boruto"}, sa)
+
+	fm, err := j.GetFloat64Map("floatMap")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"a": 1.5}, fm)
+	bm, err := j.GetBoolMap("boolMap")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true}, bm)
+
+	// also applies to the children
+	c, err := j.Child("ints")
+	assert.NoError(t, err)
+	ia, err = c.GetIntArray(".")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, ia)
+}
+
+func TestElementsStrict(t *testing.T) {
+	j, err := jsonic.NewWithOptions(readFromFile("test_data/test10.json", t), jsonic.StrictElements())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, element := range map[string]string{
+		"ints":    "[1]",
+		"floats":  "[1]",
+		"bools":   "[1]",
+		"strings": "[1]",
+	} {
+		var err error
+		switch path {
+		case "ints":
+			_, err = j.GetIntArray(path)
+		case "floats":
+			_, err = j.GetFloatArray(path)
+		case "bools":
+			_, err = j.GetBoolArray(path)
+		case "strings":
+			_, err = j.GetStringArray(path)
+		}
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidType), path)
+		var e *jsonic.PathError
+		assert.True(t, errors.As(err, &e), path)
+		assert.Equal(t, path, e.Path)
+		assert.Equal(t, element, e.Element, path)
+	}
+
+	_, err = j.GetInt64Array("ints")
+	var e *jsonic.PathError
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, jsonic.KindNumber, e.Expected)
+	assert.Equal(t, jsonic.KindString, e.Actual)
+	assert.Equal(t, `jsonic: path "ints", element 0 "ints", at [1]: expected number, found string: `+
+		`data at the specified path does not match the expected type`, err.Error())
+
+	_, err = j.GetIntMap("intMap")
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, `["b"]`, e.Element)
+	assert.Equal(t, jsonic.KindString, e.Actual)
+	_, err = j.GetInt64Map("intMap")
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, `["b"]`, e.Element)
+	_, err = j.GetFloatMap("floatMap")
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, `["b"]`, e.Element)
+	assert.Equal(t, jsonic.KindBool, e.Actual)
+	_, err = j.GetBoolMap("boolMap")
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, `["b"]`, e.Element)
+	assert.Equal(t, jsonic.KindNumber, e.Actual)
+	_, err = jsonic.MustCompile("stringMap").GetStringMap(j)
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, `["b"]`, e.Element)
+	assert.Equal(t, jsonic.KindObject, e.Actual)
+
+	// the data itself not matching
+	ia, err := j.GetIntArray("intMap.*")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	assert.Nil(t, ia)
+	m, err := j.GetFloat64Map("floats")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	assert.Nil(t, m)
+}
@@ -0,0 +1,282 @@
+package jsonic
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// EncodeOption is used to configure the json encoding of the data.
+type EncodeOption func(*encoder)
+
+type encoder struct {
+	prefix     string
+	indent     string
+	pretty     bool
+	sortKeys   bool
+	escapeHTML bool
+	buf        []byte
+}
+
+const hex = "0123456789abcdef"
+
+// Indent is used to get a pretty output, where each json element
+// begins on a new line beginning with the prefix, followed by one
+// or more copies of indent according to the nesting.
+func Indent(prefix, indent string) EncodeOption {
+	return func(e *encoder) {
+		e.prefix = prefix
+		e.indent = indent
+		e.pretty = true
+	}
+}
+
+// SortKeys is used to specify whether the keys of the objects should be sorted.
+// It is enabled by default, and when disabled the order of the keys is unspecified.
+func SortKeys(sortKeys bool) EncodeOption {
+	return func(e *encoder) {
+		e.sortKeys = sortKeys
+	}
+}
+
+// EscapeHTML is used to specify whether the characters <, > and & should be
+// escaped inside the json strings. It is enabled by default.
+func EscapeHTML(escapeHTML bool) EncodeOption {
+	return func(e *encoder) {
+		e.escapeHTML = escapeHTML
+	}
+}
+
+// Bytes returns the compact json encoding of the data, with the keys sorted.
+func (j *Jsonic) Bytes() ([]byte, error) {
+	return j.Encode()
+}
+
+// BytesIndent returns the pretty json encoding of the data, with the keys sorted.
+func (j *Jsonic) BytesIndent(prefix, indent string) ([]byte, error) {
+	return j.Encode(Indent(prefix, indent))
+}
+
+// MarshalJSON returns the json encoding of the data, so that a Jsonic
+// can be used directly with the encoding/json package.
+func (j *Jsonic) MarshalJSON() ([]byte, error) {
+	return j.Bytes()
+}
+
+// Encode returns the json encoding of the data, as per the options provided.
+//
+// By default, the output is compact, the keys are sorted and the html
+// characters are escaped, just like it is done by the encoding/json package.
+func (j *Jsonic) Encode(opts ...EncodeOption) ([]byte, error) {
+	e := newEncoder(opts)
+	err := e.encode(j.value(), 0)
+	if err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+// WriteTo writes the compact json encoding of the data to the writer.
+func (j *Jsonic) WriteTo(w io.Writer) (int64, error) {
+	return j.EncodeTo(w)
+}
+
+// EncodeTo writes the json encoding of the data to the writer, as per the options provided.
+func (j *Jsonic) EncodeTo(w io.Writer, opts ...EncodeOption) (int64, error) {
+	b, err := j.Encode(opts...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+func newEncoder(opts []EncodeOption) *encoder {
+	e := &encoder{
+		sortKeys:   true,
+		escapeHTML: true,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (e *encoder) encode(data interface{}, depth int) error {
+	switch v := data.(type) {
+	case nil:
+		e.buf = append(e.buf, "null"...)
+	case bool:
+		e.buf = strconv.AppendBool(e.buf, v)
+	case string:
+		e.encodeString(v)
+	case float64:
+		return e.encodeFloat(v)
+	case json.Number:
+		if v == empty {
+			v = "0"
+		}
+		e.buf = append(e.buf, v...)
+	case map[string]interface{}:
+		return e.encodeObject(v, depth)
+	case []interface{}:
+		return e.encodeArray(v, depth)
+	default:
+		// not something unmarshalled from json, so let the standard library handle it
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if e.pretty {
+			var buf bytes.Buffer
+			err = json.Indent(&buf, b, e.prefix+strings.Repeat(e.indent, depth), e.indent)
+			if err != nil {
+				return err
+			}
+			b = buf.Bytes()
+		}
+		e.buf = append(e.buf, b...)
+	}
+	return nil
+}
+
+func (e *encoder) encodeObject(object map[string]interface{}, depth int) error {
+	if len(object) == 0 {
+		e.buf = append(e.buf, "{}"...)
+		return nil
+	}
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	if e.sortKeys {
+		sort.Strings(keys)
+	}
+	e.buf = append(e.buf, '{')
+	for i, k := range keys {
+		if i > 0 {
+			e.buf = append(e.buf, ',')
+		}
+		e.newLine(depth + 1)
+		e.encodeString(k)
+		e.buf = append(e.buf, ':')
+		if e.pretty {
+			e.buf = append(e.buf, ' ')
+		}
+		err := e.encode(object[k], depth+1)
+		if err != nil {
+			return err
+		}
+	}
+	e.newLine(depth)
+	e.buf = append(e.buf, '}')
+	return nil
+}
+
+func (e *encoder) encodeArray(array []interface{}, depth int) error {
+	if len(array) == 0 {
+		e.buf = append(e.buf, "[]"...)
+		return nil
+	}
+	e.buf = append(e.buf, '[')
+	for i, v := range array {
+		if i > 0 {
+			e.buf = append(e.buf, ',')
+		}
+		e.newLine(depth + 1)
+		err := e.encode(v, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+	e.newLine(depth)
+	e.buf = append(e.buf, ']')
+	return nil
+}
+
+func (e *encoder) newLine(depth int) {
+	if !e.pretty {
+		return
+	}
+	e.buf = append(e.buf, '\n')
+	e.buf = append(e.buf, e.prefix...)
+	for i := 0; i < depth; i++ {
+		e.buf = append(e.buf, e.indent...)
+	}
+}
+
+func (e *encoder) encodeFloat(f float64) error {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return &json.UnsupportedValueError{Value: reflect.ValueOf(f), Str: strconv.FormatFloat(f, 'g', -1, 64)}
+	}
+	// same format as the one used by the encoding/json package
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	e.buf = strconv.AppendFloat(e.buf, f, format, -1, 64)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(e.buf)
+		if n >= 4 && e.buf[n-4] == 'e' && e.buf[n-3] == '-' && e.buf[n-2] == '0' {
+			e.buf[n-2] = e.buf[n-1]
+			e.buf = e.buf[:n-1]
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeString(s string) {
+	e.buf = append(e.buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && (!e.escapeHTML || (b != '<' && b != '>' && b != '&')) {
+				i++
+				continue
+			}
+			e.buf = append(e.buf, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				e.buf = append(e.buf, '\\', b)
+			case '\n':
+				e.buf = append(e.buf, '\\', 'n')
+			case '\r':
+				e.buf = append(e.buf, '\\', 'r')
+			case '\t':
+				e.buf = append(e.buf, '\\', 't')
+			default:
+				e.buf = append(e.buf, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			// invalid utf-8 is replaced with the replacement character
+			e.buf = append(e.buf, s[start:i]...)
+			e.buf = append(e.buf, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			// these are valid in json, but not in javascript
+			e.buf = append(e.buf, s[start:i]...)
+			e.buf = append(e.buf, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	e.buf = append(e.buf, s[start:]...)
+	e.buf = append(e.buf, '"')
+}
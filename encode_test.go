@@ -0,0 +1,116 @@
+package jsonic_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes(t *testing.T) {
+	data := readFromFile("test_data/test1.json", t)
+	j, err := jsonic.New(data)
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	var expected interface{}
+	err = json.Unmarshal(data, &expected)
+	assert.NoError(t, err)
+	e, err := json.Marshal(expected)
+	assert.NoError(t, err)
+
+	b, err := j.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, string(e), string(b))
+
+	// child
+	c, err := j.Child("a.arr.[0]")
+	assert.NoError(t, err)
+	b, err = c.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":"b","c.d":{"e":"f"}}`, string(b))
+
+	// scalars
+	c, err = j.Child("c")
+	assert.NoError(t, err)
+	b, err = c.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, `"d"`, string(b))
+}
+
+func TestBytesIndent(t *testing.T) {
+	data := readFromFile("test_data/test2.json", t)
+	j, err := jsonic.New(data)
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	var expected interface{}
+	err = json.Unmarshal(data, &expected)
+	assert.NoError(t, err)
+	e, err := json.MarshalIndent(expected, "", "  ")
+	assert.NoError(t, err)
+
+	b, err := j.BytesIndent("", "  ")
+	assert.NoError(t, err)
+	assert.Equal(t, string(e), string(b))
+
+	e, err = json.MarshalIndent(expected, ">", "\t")
+	assert.NoError(t, err)
+	b, err = j.Encode(jsonic.Indent(">", "\t"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(e), string(b))
+}
+
+func TestEncodeOptions(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"b": "<naruto & boruto>", "a": [], "c": {}, "d": 1e-7, "e": "\u2028\n\u0001"}`))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	b, err := j.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[],"b":"\u003cnaruto \u0026 boruto\u003e","c":{},"d":1e-7,"e":"\u2028\n\u0001"}`, string(b))
+
+	b, err = j.Encode(jsonic.EscapeHTML(false))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[],"b":"<naruto & boruto>","c":{},"d":1e-7,"e":"\u2028\n\u0001"}`, string(b))
+
+	b, err = j.Encode(jsonic.SortKeys(false))
+	assert.NoError(t, err)
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	assert.NoError(t, err)
+	assert.Equal(t, "<naruto & boruto>", m["b"])
+	assert.Len(t, m, 5)
+}
+
+func TestWriteTo(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test3.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	var buf bytes.Buffer
+	n, err := j.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(16), n)
+	assert.Equal(t, `{"":"b",".":"a"}`, buf.String())
+
+	buf.Reset()
+	n, err = j.EncodeTo(&buf, jsonic.Indent("", " "))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, "{\n \"\": \"b\",\n \".\": \"a\"\n}", buf.String())
+}
+
+func TestMarshalJSON(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	c, err := j.Child("a.arr")
+	assert.NoError(t, err)
+	b, err := json.Marshal(map[string]interface{}{"forwarded": c})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"forwarded":[{"a":"b","c.d":{"e":"f"}}]}`, string(b))
+}
@@ -1,6 +1,10 @@
 package jsonic
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
 
 // errors
 var (
@@ -9,4 +13,146 @@ var (
 	ErrIndexOutOfBound    = errors.New("index out of bounds of the json array")
 	ErrNoDataFound        = errors.New("no tree satisfies the path elements provided")
 	ErrInvalidType        = errors.New("data at the specified path does not match the expected type")
+	ErrInvalidPath        = errors.New("path provided is not valid")
+	ErrOverflow           = errors.New("number at the specified path does not fit in the expected type")
+	ErrDeleteRoot         = errors.New("root of the json tree cannot be deleted")
+	ErrPrecision          = errors.New("number at the specified path cannot be converted without losing precision")
+	ErrInvalidJSON        = errors.New("data provided is not a valid json")
+	ErrInvalidPatch       = errors.New("patch provided is not valid")
+	ErrTestFailed         = errors.New("data at the specified path is not the one expected by the patch")
+	ErrConflict           = errors.New("data at the specified path conflicts with the one merged already")
 )
+
+// PathError is returned when the data at the path cannot be retrieved.
+//
+// It wraps one of the errors above, which can be checked using errors.Is.
+type PathError struct {
+	// Path is the path provided.
+	Path string
+	// Segment is the index of the path element which could not be resolved,
+	// or the one resolving to the data of the unexpected kind.
+	// It is -1 in case the path itself is not valid, or it refers to the root.
+	Segment int
+	// Expected is the kind of the data expected, either to resolve the path
+	// element, or to be returned.
+	Expected Kind
+	// Actual is the kind of the data found.
+	Actual Kind
+	// Element is the index like [1], or the quoted key like ["a"], of the element of
+	// the array or the object at the path, which does not match the expected kind.
+	// In such a case, the kinds are of the element. Otherwise, it is empty.
+	Element string
+	// Err is the reason of the failure.
+	Err error
+
+	key string
+}
+
+// elementError is returned when an element of the typed arrays and maps cannot be converted.
+type elementError struct {
+	element  string
+	expected Kind
+	actual   Kind
+	err      error
+}
+
+// Error returns the description along with the reason of the failure.
+func (e *PathError) Error() string {
+	var b strings.Builder
+	b.WriteString("jsonic: path ")
+	b.WriteString(strconv.Quote(e.Path))
+	if e.Segment >= 0 {
+		b.WriteString(", element ")
+		b.WriteString(strconv.Itoa(e.Segment))
+		b.WriteString(" ")
+		b.WriteString(strconv.Quote(e.key))
+	}
+	if e.Element != empty {
+		b.WriteString(", at ")
+		b.WriteString(e.Element)
+	}
+	if e.Expected&e.Actual == 0 && e.Expected != 0 {
+		b.WriteString(": expected ")
+		b.WriteString(e.Expected.String())
+		b.WriteString(", found ")
+		b.WriteString(e.Actual.String())
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+// Unwrap returns the reason of the failure.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// LineError is returned when a line of the json lines is not a valid json.
+type LineError struct {
+	// Line is the number of the line, beginning with 1.
+	Line int
+	// Err is the reason of the failure.
+	Err error
+}
+
+// Error returns the description along with the reason of the failure.
+func (e *LineError) Error() string {
+	return "jsonic: line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the reason of the failure.
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// PatchError is returned when an operation of the patch cannot be applied.
+type PatchError struct {
+	// Operation is the index of the operation in the patch, beginning with 0.
+	Operation int
+	// Op is the name of the operation, like add.
+	Op string
+	// Path is the json pointer the operation is applied at.
+	Path string
+	// Err is the reason of the failure.
+	Err error
+}
+
+// Error returns the description along with the reason of the failure.
+func (e *PatchError) Error() string {
+	return "jsonic: patch operation " + strconv.Itoa(e.Operation) + " " + strconv.Quote(e.Op) +
+		" at " + strconv.Quote(e.Path) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the reason of the failure.
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// MergeError is returned when the json trees being merged conflict.
+type MergeError struct {
+	// Pointer is the json pointer of the data in the merged json tree.
+	Pointer string
+	// Source is the index of the json tree conflicting with the ones before it.
+	Source int
+	// Err is the reason of the failure.
+	Err error
+}
+
+// Error returns the description along with the reason of the failure.
+func (e *MergeError) Error() string {
+	return "jsonic: merge of the json tree " + strconv.Itoa(e.Source) + " at " + strconv.Quote(e.Pointer) +
+		": " + e.Err.Error()
+}
+
+// Unwrap returns the reason of the failure.
+func (e *MergeError) Unwrap() error {
+	return e.Err
+}
+
+func newElementError(element string, data interface{}, expected Kind, err error) *elementError {
+	return &elementError{element: element, expected: expected, actual: kindOf(data), err: err}
+}
+
+func (e *elementError) Error() string {
+	return e.err.Error()
+}
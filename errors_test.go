@@ -0,0 +1,166 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func pathError(t *testing.T, err error) *jsonic.PathError {
+	var e *jsonic.PathError
+	assert.True(t, errors.As(err, &e))
+	return e
+}
+
+func TestPathErrorNotFound(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	_, err = j.Get("a.m")
+	e := pathError(t, err)
+	assert.Equal(t, "a.m", e.Path)
+	assert.Equal(t, 1, e.Segment)
+	assert.Equal(t, jsonic.KindObject, e.Expected)
+	assert.Equal(t, jsonic.KindObject, e.Actual)
+	assert.Equal(t, jsonic.ErrNoDataFound, e.Err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Equal(t, `jsonic: path "a.m", element 1 "m": no tree satisfies the path elements provided`, err.Error())
+
+	_, err = j.GetString("c.x.y")
+	e = pathError(t, err)
+	assert.Equal(t, 1, e.Segment)
+	assert.Equal(t, jsonic.KindObject, e.Expected)
+	assert.Equal(t, jsonic.KindString, e.Actual)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Equal(t, `jsonic: path "c.x.y", element 1 "x": expected object, found string: `+
+		`no tree satisfies the path elements provided`, err.Error())
+
+	// the deepest failure is reported among all the possibilities
+	_, err = j.Get("a.x.y.z.w")
+	e = pathError(t, err)
+	assert.Equal(t, 4, e.Segment)
+	assert.Equal(t, jsonic.KindString, e.Actual)
+
+	_, err = j.GetMap("a.arr[3].a")
+	e = pathError(t, err)
+	assert.Equal(t, 2, e.Segment)
+	assert.Equal(t, jsonic.KindArray, e.Expected)
+	assert.Equal(t, jsonic.KindArray, e.Actual)
+
+	// descendants
+	_, err = j.Query("..arr[*].x")
+	assert.NoError(t, err)
+	_, err = j.Child("..arr[*].x")
+	e = pathError(t, err)
+	assert.Equal(t, 3, e.Segment)
+	assert.Equal(t, jsonic.KindObject, e.Actual)
+}
+
+func TestPathErrorOnArray(t *testing.T) {
+	j, err := jsonic.New([]byte(`[[1, 2], {"a": [3]}]`))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	_, err = j.GetInt("[0][5]")
+	e := pathError(t, err)
+	assert.Equal(t, 1, e.Segment)
+	assert.Equal(t, jsonic.KindArray, e.Expected)
+	assert.True(t, errors.Is(err, jsonic.ErrIndexOutOfBound))
+
+	_, err = j.GetInt("[1][0]")
+	e = pathError(t, err)
+	assert.Equal(t, 1, e.Segment)
+	assert.Equal(t, jsonic.KindArray, e.Expected)
+	assert.Equal(t, jsonic.KindObject, e.Actual)
+
+	_, err = j.GetInt("/1/a/1")
+	e = pathError(t, err)
+	assert.Equal(t, "/1/a/1", e.Path)
+	assert.Equal(t, 2, e.Segment)
+	assert.Equal(t, jsonic.KindArray|jsonic.KindObject, e.Expected)
+	assert.Equal(t, jsonic.KindArray, e.Actual)
+}
+
+func TestPathErrorType(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	_, err = j.GetInt("a.arr[0].a")
+	e := pathError(t, err)
+	assert.Equal(t, 3, e.Segment)
+	assert.Equal(t, jsonic.KindNumber, e.Expected)
+	assert.Equal(t, jsonic.KindString, e.Actual)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	assert.Equal(t, `jsonic: path "a.arr[0].a", element 3 "a": expected number, found string: `+
+		`data at the specified path does not match the expected type`, err.Error())
+
+	_, err = j.GetStringArray("a")
+	e = pathError(t, err)
+	assert.Equal(t, 0, e.Segment)
+	assert.Equal(t, jsonic.KindArray, e.Expected)
+	assert.Equal(t, jsonic.KindObject, e.Actual)
+
+	_, err = j.GetBool(".")
+	e = pathError(t, err)
+	assert.Equal(t, -1, e.Segment)
+	assert.Equal(t, jsonic.KindBool, e.Expected)
+	assert.Equal(t, jsonic.KindObject, e.Actual)
+	assert.Equal(t, `jsonic: path ".": expected bool, found object: `+
+		`data at the specified path does not match the expected type`, err.Error())
+
+	_, err = jsonic.MustCompile("c").GetFloat64Map(j)
+	e = pathError(t, err)
+	assert.Equal(t, jsonic.KindObject, e.Expected)
+	assert.Equal(t, jsonic.KindString, e.Actual)
+}
+
+func TestPathErrorInvalidPath(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	_, err = j.GetString(`a["b`)
+	e := pathError(t, err)
+	assert.Equal(t, `a["b`, e.Path)
+	assert.Equal(t, -1, e.Segment)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+	assert.Equal(t, `jsonic: path "a[\"b": path provided is not valid`, err.Error())
+
+	_, err = jsonic.Compile(`a\`)
+	e = pathError(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+
+	_, err = j.GetPointer("/a~")
+	e = pathError(t, err)
+	assert.Equal(t, -1, e.Segment)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+}
+
+func TestKind(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": null, "b": true, "c": 1, "d": "e", "f": [], "g": {}}`))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	assert.Equal(t, jsonic.KindObject, j.Kind())
+	for path, kind := range map[string]jsonic.Kind{
+		"a": jsonic.KindNull,
+		"b": jsonic.KindBool,
+		"c": jsonic.KindNumber,
+		"d": jsonic.KindString,
+		"f": jsonic.KindArray,
+		"g": jsonic.KindObject,
+	} {
+		c, err := j.Child(path)
+		assert.NoError(t, err)
+		assert.Equal(t, kind, c.Kind(), path)
+	}
+
+	assert.Equal(t, "null", jsonic.KindNull.String())
+	assert.Equal(t, "number", jsonic.KindNumber.String())
+	assert.Equal(t, "array or object", (jsonic.KindArray | jsonic.KindObject).String())
+	assert.Equal(t, "unknown", jsonic.Kind(0).String())
+}
@@ -0,0 +1,188 @@
+package jsonic
+
+import (
+	"regexp"
+	"strings"
+)
+
+// jpRegexMatch checks whether the value is a string matching the regular expression.
+type jpRegexMatch struct {
+	operand jpComparable
+	re      *regexp.Regexp
+}
+
+// the operator to match the regular expressions in the filters inside the paths
+const regexMatch = "=~"
+
+// the functions available in the filters inside the paths
+var filterFunctions = map[string]*jpFunction{
+	"length":      jpFunctions["length"],
+	"count":       jpFunctions["count"],
+	"match":       jpFunctions["match"],
+	"search":      jpFunctions["search"],
+	"value":       jpFunctions["value"],
+	"contains":    {params: []int{jpValueType, jpValueType}, result: jpLogicalType, call: filterContains},
+	"starts_with": {params: []int{jpValueType, jpValueType}, result: jpLogicalType, call: filterStartsWith},
+}
+
+// parseFilter parses the filter enclosed within the square brackets at the position provided,
+// like [?(@.price > 10)], and returns the position following it.
+//
+// The filter has the same syntax as the one in JSONPath, except that @ is
+// followed by a path, which is resolved in the same way as it is done in Child.
+func parseFilter(path string, start int) (jpLogical, int, error) {
+	p := &jpParser{expr: path, pos: start + 2, functions: filterFunctions, dotted: true}
+	p.skipBlanks()
+	filter, err := p.parseLogical()
+	if err != nil {
+		return nil, 0, ErrInvalidPath
+	}
+	p.skipBlanks()
+	if p.peek() != ']' {
+		return nil, 0, ErrInvalidPath
+	}
+	return filter, p.pos + 1, nil
+}
+
+// parseDottedQuery parses the path following @, which ends at the first blank,
+// operator, comma or closing parenthesis outside the square brackets.
+func (p *jpParser) parseDottedQuery() (jpOperand, error) {
+	if strings.HasPrefix(p.expr[p.pos:], dot) && !strings.HasPrefix(p.expr[p.pos:], descent) {
+		p.pos++
+	}
+	start := p.pos
+	depth := 0
+	var quote byte
+loop:
+	for ; p.pos < len(p.expr); p.pos++ {
+		c := p.expr[p.pos]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				p.pos++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\\':
+			p.pos++
+		case depth > 0 && (c == '"' || c == '\''):
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			if depth == 0 {
+				break loop
+			}
+			depth--
+		case depth == 0 && strings.IndexByte(" \t\n\r(),=!<>&|", c) >= 0:
+			break loop
+		}
+	}
+	if p.pos > len(p.expr) {
+		p.pos = len(p.expr)
+	}
+	query := &jpQuery{relative: true, singular: true, dotted: true}
+	if p.pos > start {
+		path, err := parsePath(p.expr[start:p.pos])
+		if err != nil {
+			p.pos = start
+			return jpOperand{}, p.errorf("invalid path")
+		}
+		query.path = path
+	}
+	return jpOperand{query: query}, nil
+}
+
+// parseRegexMatch parses the regular expression to be matched with the operand, either
+// quoted or enclosed within slashes, like @.name =~ /^na/.
+func (p *jpParser) parseRegexMatch(left jpOperand, start int) (jpLogical, error) {
+	operand, err := p.comparableOf(left, start)
+	if err != nil {
+		return nil, err
+	}
+	p.pos += len(regexMatch)
+	p.skipBlanks()
+	var pattern string
+	switch p.peek() {
+	case '/':
+		pattern, err = p.parseSlashed()
+	case '\'', '"':
+		pattern, err = p.parseString()
+	default:
+		err = p.errorf("expected regular expression")
+	}
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, p.errorf("invalid regular expression")
+	}
+	return jpRegexMatch{operand: operand, re: re}, nil
+}
+
+// parseSlashed parses the regular expression enclosed within the slashes,
+// where an escaped slash stands for the slash itself.
+func (p *jpParser) parseSlashed() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for ; p.pos < len(p.expr); p.pos++ {
+		c := p.expr[p.pos]
+		if c == '/' {
+			p.pos++
+			return b.String(), nil
+		}
+		if c == '\\' && p.pos+1 < len(p.expr) && p.expr[p.pos+1] == '/' {
+			p.pos++
+			c = '/'
+		}
+		b.WriteByte(c)
+	}
+	return empty, p.errorf("regular expression not terminated")
+}
+
+func (e jpRegexMatch) test(current, root *Jsonic) bool {
+	s, ok := e.operand.value(current, root).(string)
+	return ok && e.re.MatchString(s)
+}
+
+// dottedNodes returns all the json trees at the path, as done in Query.
+func (j *Jsonic) dottedNodes(path []pathElement) []*Jsonic {
+	results, _ := j.children(path, -1, nil)
+	return results
+}
+
+// selectedByFilter returns the elements of an array, or the values of an object, satisfying the filter.
+func (j *Jsonic) selectedByFilter(filter jpLogical) []*Jsonic {
+	var selected []*Jsonic
+	for _, child := range j.selected(pathElement{nature: natureWildcard}) {
+		if filter.test(child, j) {
+			selected = append(selected, child)
+		}
+	}
+	return selected
+}
+
+func filterContains(args []interface{}) interface{} {
+	switch v := args[0].(type) {
+	case string:
+		s, ok := args[1].(string)
+		return ok && strings.Contains(v, s)
+	case []interface{}:
+		if args[1] == nothing {
+			return false
+		}
+		for _, e := range v {
+			if equalValues(e, args[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func filterStartsWith(args []interface{}) interface{} {
+	s, ok := args[0].(string)
+	prefix, okPrefix := args[1].(string)
+	return ok && okPrefix && strings.HasPrefix(s, prefix)
+}
@@ -0,0 +1,152 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func queryIDs(t *testing.T, j *jsonic.Jsonic, path string) []int {
+	results, err := j.Query(path)
+	assert.NoError(t, err, path)
+	ids := make([]int, 0, len(results))
+	for _, r := range results {
+		id, err := r.GetInt("id")
+		assert.NoError(t, err, path)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestFilterComparison(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test9.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, ids := range map[string][]int{
+		"items[?(@.price > 10)]":           {2, 3, 4},
+		"items[?(@.price >= 12)]":          {2, 3},
+		"items[?(@.price < 10.5)]":         {1},
+		"items[?(@.price <= 10.5)]":        {1, 4},
+		"items[?(@.id == 3)]":              {3},
+		"items[?(@.id != 3)]":              {1, 2, 4},
+		"items[?(@.name == 'naruto')]":     {1},
+		"items[?(@.name > \"r\")]":         {2},
+		"items[?@.id==2]":                  {2},
+		"items[?(@.meta.rank == 'jonin')]": {3, 4},
+		"items[?(@.tags[0] == 'leaf')]":    {4},
+		"items[?(@.tags[-1] == 'leaf')]":   {1},
+		"items[?(@.price > '10')]":         {},
+		"items[?(@.missing == null)]":      {},
+		"items[?(@.a.b == true)]":          {3},
+		"items[?(@[\"a.b\"] == true)]":     {3},
+		"items[?(@.meta == @.meta)]":       {1, 2, 3, 4},
+	} {
+		assert.Equal(t, ids, queryIDs(t, j, path), path)
+	}
+}
+
+func TestFilterLogical(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test9.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, ids := range map[string][]int{
+		"items[?(@.price > 10 && @.meta.rank == 'jonin')]":   {3, 4},
+		"items[?(@.id == 1 || @.id == 4)]":                   {1, 4},
+		"items[?(@.id == 1 || @.id == 2 && @.price > 100)]":  {1},
+		"items[?((@.id == 1 || @.id == 2) && @.price > 10)]": {2},
+		"items[?(!(@.id == 1))]":                             {2, 3, 4},
+		"items[?(@.isbn)]":                                   {2},
+		"items[?(!@.isbn)]":                                  {1, 3, 4},
+		"items[?(@.tags[1])]":                                {1, 4},
+		"items[?(@.isbn || @.a.b)]":                          {2, 3},
+	} {
+		assert.Equal(t, ids, queryIDs(t, j, path), path)
+	}
+}
+
+func TestFilterFunctions(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test9.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for path, ids := range map[string][]int{
+		"items[?(@.name =~ /^s/)]":                {2},
+		"items[?(@.name =~ /(?i)^s/)]":            {2, 4},
+		"items[?(@.name =~ 'k.*i')]":              {3},
+		"items[?(@.meta.rank =~ /^j\\/?o/)]":      {3, 4},
+		"items[?(@.id =~ /1/)]":                   {},
+		"items[?(length(@.tags) == 2)]":           {1, 4},
+		"items[?(length(@.name) > 6)]":            {3},
+		"items[?(length(@.tags) == 0)]":           {3},
+		"items[?(contains(@.tags, 'leaf'))]":      {1, 4},
+		"items[?(contains(@.name, 'su'))]":        {2},
+		"items[?(!contains(@.tags, 'ninja'))]":    {3, 4},
+		"items[?(starts_with(@.name, 'ka'))]":     {3},
+		"items[?(starts_with(@.meta.rank, 'j'))]": {3, 4},
+		"items[?(starts_with(@.id, '1'))]":        {},
+		"items[?(match(@.name, 'na.*'))]":         {1},
+		"items[?(count(@.tags[*]) == 1)]":         {2},
+	} {
+		assert.Equal(t, ids, queryIDs(t, j, path), path)
+	}
+}
+
+func TestFilterNested(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test9.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	// scalars
+	scores, err := j.Query("scores[?(@ > 5)]")
+	assert.NoError(t, err)
+	assert.Len(t, scores, 2)
+	// objects
+	assert.Equal(t, []interface{}{5.0}, queryData(t, j, "ranks[?(@.level > 1)].level"))
+	assert.Equal(t, []interface{}{"leaf", "leaf"}, queryData(t, j, "items[*].tags[?(@ == 'leaf')]"))
+	assert.Equal(t, []interface{}{"sasuke"}, queryData(t, j, "..[?(@.isbn)].name"))
+	// a filter within a filter
+	assert.Equal(t, []interface{}{"naruto", "Sakura"}, queryData(t, j, "items[?(@.tags[?(@ == 'leaf')])].name"))
+
+	// child returns the first one
+	name, err := j.GetString("items[?(@.id == 2)].name")
+	assert.NoError(t, err)
+	assert.Equal(t, "sasuke", name)
+	c, err := j.Child("items[?(@.price > 10)]")
+	assert.NoError(t, err)
+	assert.Equal(t, "/items/1", c.Pointer())
+	_, err = j.Child("items[?(@.price > 100)]")
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+}
+
+func TestFilterInvalid(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test9.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for _, path := range []string{
+		"items[?(@.id == )]",
+		"items[?(@.id == 1]",
+		"items[?(@.id == 1)",
+		"items[?()]",
+		"items[?(1)]",
+		"items[?($.id == 1)]",
+		"items[?(@.name =~ /(/)]",
+		"items[?(@.name =~ /a)]",
+		"items[?(@.name =~ 1)]",
+		"items[?(unknown(@.name))]",
+		"items[?(length(@.name))]",
+	} {
+		_, err := j.Query(path)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidPath), path)
+		_, err = j.Child(path)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidPath), path)
+	}
+
+	// filters select multiple json trees, so they cannot be modified
+	err = j.Set("items[?(@.id == 1)].name", "boruto")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+}
@@ -0,0 +1,227 @@
+package jsonic
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// GetAs is used to get the data at the path specified as the type provided.
+//
+// The type can be any of the integer types, signed or unsigned, float32, float64,
+// bool, string, time.Time written as per RFC 3339, interface{}, a pointer to any
+// of them, which is nil for null, and the slices and the maps with string keys
+// of any of these, nested to any depth. Any other type, like a struct, is decoded
+// in the same way as it is done by GetTyped.
+//
+// It returns ErrOverflow in case a number does not fit in the type. The elements
+// of the slices and the maps not matching the type are handled as per the options,
+// in the same way as it is done by the typed arrays and maps, like GetIntArray.
+func GetAs[T any](j *Jsonic, path string) (T, error) {
+	return GetPathAs[T](j, compilePath(path))
+}
+
+// GetSlice is used to get the array at the path specified as a slice of the type provided.
+func GetSlice[T any](j *Jsonic, path string) ([]T, error) {
+	return GetPathAs[[]T](j, compilePath(path))
+}
+
+// GetMapOf is used to get the object at the path specified as a map of the type provided.
+func GetMapOf[T any](j *Jsonic, path string) (map[string]T, error) {
+	return GetPathAs[map[string]T](j, compilePath(path))
+}
+
+// GetPathAs is used to get the data at the compiled path as the type provided.
+func GetPathAs[T any](j *Jsonic, p *Path) (T, error) {
+	var t T
+	val, err := p.Get(j)
+	if err != nil {
+		return t, err
+	}
+	v := reflect.ValueOf(&t).Elem()
+	err = convert(val, v, j.opts)
+	if err != nil {
+		var zero T
+		return zero, p.typeError(err, val, kindFor(v.Type()))
+	}
+	return t, nil
+}
+
+// GetPathSlice is used to get the array at the compiled path as a slice of the type provided.
+func GetPathSlice[T any](j *Jsonic, p *Path) ([]T, error) {
+	return GetPathAs[[]T](j, p)
+}
+
+// GetPathMapOf is used to get the object at the compiled path as a map of the type provided.
+func GetPathMapOf[T any](j *Jsonic, p *Path) (map[string]T, error) {
+	return GetPathAs[map[string]T](j, p)
+}
+
+// convert sets the json data in the value provided, which should be settable.
+func convert(data interface{}, v reflect.Value, o *options) error {
+	t := v.Type()
+	if t == timeType {
+		s, ok := data.(string)
+		if !ok {
+			return ErrInvalidType
+		}
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return ErrInvalidType
+		}
+		v.Set(reflect.ValueOf(tm))
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := toInt64(data, t.Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := toUint64(data, t.Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(data, t.Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := boolOf(data, nil)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.String:
+		s, err := stringOf(data, nil)
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+	case reflect.Interface:
+		if data == nil {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		d := reflect.ValueOf(data)
+		if !d.Type().AssignableTo(t) {
+			return ErrInvalidType
+		}
+		v.Set(d)
+	case reflect.Ptr:
+		if data == nil {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+		e := reflect.New(t.Elem())
+		err := convert(data, e.Elem(), o)
+		if err != nil {
+			return err
+		}
+		v.Set(e)
+	case reflect.Slice:
+		return convertSlice(data, v, o)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return ErrInvalidType
+		}
+		return convertMap(data, v, o)
+	default:
+		return decode(data, v.Addr().Interface())
+	}
+	return nil
+}
+
+func convertSlice(data interface{}, v reflect.Value, o *options) error {
+	array, err := arrayOf(data, nil)
+	if err != nil {
+		return err
+	}
+	t := v.Type().Elem()
+	s := reflect.MakeSlice(v.Type(), 0, len(array))
+	for index, element := range array {
+		e := reflect.New(t).Elem()
+		err = convert(element, e, o)
+		if err != nil {
+			if err := elementErrorOf("["+strconv.Itoa(index)+"]", element, t, err, o); err != nil {
+				return err
+			}
+			if o.elements == elementsSkip {
+				continue
+			}
+			// the value might be set partially
+			e = reflect.Zero(t)
+		}
+		s = reflect.Append(s, e)
+	}
+	v.Set(s)
+	return nil
+}
+
+func convertMap(data interface{}, v reflect.Value, o *options) error {
+	object, err := mapOf(data, nil)
+	if err != nil {
+		return err
+	}
+	t := v.Type().Elem()
+	m := reflect.MakeMapWithSize(v.Type(), len(object))
+	for k, element := range object {
+		e := reflect.New(t).Elem()
+		err = convert(element, e, o)
+		if err != nil {
+			if err := elementErrorOf("["+strconv.Quote(k)+"]", element, t, err, o); err != nil {
+				return err
+			}
+			// the keys are never added with the zero value
+			continue
+		}
+		m.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), e)
+	}
+	v.Set(m)
+	return nil
+}
+
+// elementErrorOf returns the error to be reported for the element which could not be converted,
+// or nil in case it should be ignored.
+func elementErrorOf(element string, data interface{}, t reflect.Type, err error, o *options) error {
+	if e, ok := err.(*elementError); ok {
+		// the nested element has already failed
+		e.element = element + e.element
+		return e
+	}
+	if o.failElement(err) {
+		return newElementError(element, data, kindFor(t), err)
+	}
+	return nil
+}
+
+// kindFor returns the kinds of the json data which can be converted to the type.
+func kindFor(t reflect.Type) Kind {
+	if t == timeType {
+		return KindString
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return KindNumber
+	case reflect.Bool:
+		return KindBool
+	case reflect.String:
+		return KindString
+	case reflect.Interface:
+		return KindNull | KindBool | KindNumber | KindString | KindArray | KindObject
+	case reflect.Ptr:
+		return KindNull | kindFor(t.Elem())
+	case reflect.Slice, reflect.Array:
+		return KindArray
+	}
+	return KindObject
+}
@@ -0,0 +1,220 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAsNumbers(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test12.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i8, err := jsonic.GetAs[int8](j, "small")
+	assert.NoError(t, err)
+	assert.Equal(t, int8(127), i8)
+	_, err = jsonic.GetAs[int8](j, "large")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	i16, err := jsonic.GetAs[int16](j, "large")
+	assert.NoError(t, err)
+	assert.Equal(t, int16(300), i16)
+	_, err = jsonic.GetAs[int](j, "fraction")
+	assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+
+	u8, err := jsonic.GetAs[uint8](j, "small")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(127), u8)
+	_, err = jsonic.GetAs[uint8](j, "large")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	_, err = jsonic.GetAs[uint](j, "negative")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	f32, err := jsonic.GetAs[float32](j, "fraction")
+	assert.NoError(t, err)
+	assert.Equal(t, float32(2.5), f32)
+
+	_, err = jsonic.GetAs[int](j, "name")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	var pathErr *jsonic.PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, jsonic.KindNumber, pathErr.Expected)
+	assert.Equal(t, jsonic.KindString, pathErr.Actual)
+
+	_, err = jsonic.GetAs[int](j, "x")
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+}
+
+func TestGetAsUseNumber(t *testing.T) {
+	j, err := jsonic.NewWithOptions(readFromFile("test_data/test12.json", t), jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	u64, err := jsonic.GetAs[uint64](j, "huge")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(18446744073709551615), u64)
+	_, err = jsonic.GetAs[int64](j, "huge")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	_, err = jsonic.GetAs[uint32](j, "negative")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	_, err = jsonic.GetAs[uint](j, "fraction")
+	assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+}
+
+func TestGetAsOthers(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test12.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	s, err := jsonic.GetAs[string](j, "name")
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", s)
+	b, err := jsonic.GetAs[bool](j, "active")
+	assert.NoError(t, err)
+	assert.True(t, b)
+	born, err := jsonic.GetAs[time.Time](j, "born")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC), born)
+	_, err = jsonic.GetAs[time.Time](j, "name")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+
+	p, err := jsonic.GetAs[*string](j, "nothing")
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+	p, err = jsonic.GetAs[*string](j, "name")
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", *p)
+	v, err := jsonic.GetAs[interface{}](j, "small")
+	assert.NoError(t, err)
+	assert.Equal(t, 127.0, v)
+
+	type character struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	c, err := jsonic.GetAs[character](j, "character")
+	assert.NoError(t, err)
+	assert.Equal(t, character{Name: "naruto", Age: 17}, c)
+
+	type name string
+	n, err := jsonic.GetAs[name](j, "name")
+	assert.NoError(t, err)
+	assert.Equal(t, name("naruto"), n)
+
+	n, err = jsonic.GetPathAs[name](j, jsonic.MustCompile("character.name"))
+	assert.NoError(t, err)
+	assert.Equal(t, name("naruto"), n)
+}
+
+func TestGetSliceAndMapOf(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test12.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	bytes, err := jsonic.GetSlice[uint8](j, "bytes")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint8{1, 2, 255}, bytes)
+	matrix, err := jsonic.GetSlice[[]int](j, "matrix")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, matrix)
+	matrix, err = jsonic.GetSlice[[]int](j, "invalid")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3, 0}}, matrix)
+	pointers, err := jsonic.GetSlice[*int](j, "pointers")
+	assert.NoError(t, err)
+	assert.Len(t, pointers, 3)
+	assert.Equal(t, 1, *pointers[0])
+	assert.Nil(t, pointers[1])
+	assert.Equal(t, 3, *pointers[2])
+
+	dates, err := jsonic.GetMapOf[time.Time](j, "dates")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"a": time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)}, dates)
+	nested, err := jsonic.GetMapOf[map[string]int](j, "nested")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]int{"a": {"x": 1}, "b": {"y": 2}}, nested)
+
+	_, err = jsonic.GetSlice[int](j, "nested")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	_, err = jsonic.GetSlice[int8](j, "bytes")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	var pathErr *jsonic.PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "[2]", pathErr.Element)
+
+	s, err := jsonic.GetPathSlice[int](j, jsonic.MustCompile("matrix[1]"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, s)
+	m, err := jsonic.GetPathMapOf[int](j, jsonic.MustCompile("nested.a"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"x": 1}, m)
+}
+
+func TestGetFractionElements(t *testing.T) {
+	data := []byte(`{"a": [1.5, 2], "m": {"x": 1.5, "y": 2}, "nested": [[1], [2, 3.5]]}`)
+	for _, opts := range [][]jsonic.Option{nil, {jsonic.SkipElements()}, {jsonic.UseNumber()}} {
+		j, err := jsonic.NewWithOptions(data, opts...)
+		assert.NoError(t, err)
+		var pathErr *jsonic.PathError
+
+		iArr, err := j.GetIntArray("a")
+		assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+		assert.True(t, errors.As(err, &pathErr))
+		assert.Equal(t, "[0]", pathErr.Element)
+		assert.Nil(t, iArr)
+		_, err = j.GetInt64Array("a")
+		assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+
+		iMap, err := j.GetIntMap("m")
+		assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+		assert.True(t, errors.As(err, &pathErr))
+		assert.Equal(t, `["x"]`, pathErr.Element)
+		assert.Nil(t, iMap)
+		_, err = j.GetInt64Map("m")
+		assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+
+		_, err = jsonic.GetSlice[int](j, "a")
+		assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+		_, err = jsonic.GetSlice[uint16](j, "a")
+		assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+		_, err = jsonic.GetMapOf[int](j, "m")
+		assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+		_, err = jsonic.GetSlice[[]int](j, "nested")
+		assert.True(t, errors.As(err, &pathErr))
+		assert.Equal(t, "[1][1]", pathErr.Element)
+		assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+
+		// the floating point numbers are as they are
+		fArr, err := jsonic.GetSlice[float64](j, "a")
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{1.5, 2}, fArr)
+	}
+}
+
+func TestGetSliceStrict(t *testing.T) {
+	j, err := jsonic.NewWithOptions(readFromFile("test_data/test12.json", t), jsonic.StrictElements())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	_, err = jsonic.GetSlice[[]int](j, "invalid")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	var pathErr *jsonic.PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "[1][1]", pathErr.Element)
+	assert.Equal(t, jsonic.KindNumber, pathErr.Expected)
+	assert.Equal(t, jsonic.KindString, pathErr.Actual)
+
+	_, err = jsonic.GetMapOf[time.Time](j, "dates")
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, `["b"]`, pathErr.Element)
+
+	j, err = jsonic.NewWithOptions(readFromFile("test_data/test12.json", t), jsonic.SkipElements())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	matrix, err := jsonic.GetSlice[[]int](j, "invalid")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3}}, matrix)
+}
@@ -0,0 +1,424 @@
+package jsonic
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf8"
+)
+
+// the elements used to resolve the dot and the empty paths, same as getDotOrEmptyChild
+var (
+	dotElements   = []pathElement{{nature: natureKey, key: dot, exact: true}}
+	emptyElements = []pathElement{{nature: natureKey, key: empty, exact: true}}
+)
+
+// rawScanner finds the value at the path in the json data, without unmarshalling it.
+type rawScanner struct {
+	data []byte
+	// the index of the deepest path element reached, and the kind of the data there
+	deepest int
+	at      Kind
+}
+
+// GetBytes is used to get the json value at the path specified, directly from the json data.
+//
+// The path is resolved in the same way as it is done in Child, but instead of unmarshalling
+// the data, it is scanned, skipping the values not required, and the part of the data
+// holding the value is returned. So there is no need to create a Jsonic to get a few values.
+//
+// The data is only validated as much as it is scanned, and ErrInvalidJSON is returned
+// in case it is not valid. Note that the paths selecting multiple json trees, like the ones
+// with wildcards, are resolved using a Jsonic in the lazy mode, and so they are not as cheap.
+func GetBytes(data []byte, path string) ([]byte, error) {
+	return compilePath(path).GetBytes(data)
+}
+
+// GetBytesInt is used to get the integer at the path specified, directly from the json data.
+func GetBytesInt(data []byte, path string) (int, error) {
+	return compilePath(path).GetBytesInt(data)
+}
+
+// GetBytesInt64 is used to get the 64-bit integer at the path specified, directly from the json data.
+//
+// The number is parsed exactly, same as it is done with the UseNumber option.
+func GetBytesInt64(data []byte, path string) (int64, error) {
+	return compilePath(path).GetBytesInt64(data)
+}
+
+// GetBytesFloat64 is used to get the 64-bit floating point number at the path specified,
+// directly from the json data.
+func GetBytesFloat64(data []byte, path string) (float64, error) {
+	return compilePath(path).GetBytesFloat64(data)
+}
+
+// GetBytesBool is used to get the boolean at the path specified, directly from the json data.
+func GetBytesBool(data []byte, path string) (bool, error) {
+	return compilePath(path).GetBytesBool(data)
+}
+
+// GetBytesString is used to get the string at the path specified, directly from the json data.
+func GetBytesString(data []byte, path string) (string, error) {
+	return compilePath(path).GetBytesString(data)
+}
+
+// GetBytes is used to get the json value at this path, directly from the json data,
+// in the same way as it is done by the GetBytes function.
+//
+// As the path is already parsed, scanning the data does not allocate.
+func (p *Path) GetBytes(data []byte) ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	start := skipSpace(data, 0)
+	if start == len(data) {
+		return nil, ErrInvalidJSON
+	}
+	s := rawScanner{data: data, at: rawKind(data[start:])}
+	switch {
+	case p.path == dot || p.path == empty:
+		elements := dotElements
+		if p.path == empty {
+			elements = emptyElements
+		}
+		if data[start] == '{' {
+			begin, end, err := s.find(start, elements, 0, 0)
+			if err == nil || err == ErrInvalidJSON {
+				return data[begin:end], err
+			}
+		}
+		// in any other scenario it is the whole data
+		return s.value(start)
+	case !p.singular():
+		return p.getBytesFromTree(data)
+	}
+	begin, end, err := s.find(start, p.elements, 0, 0)
+	if err != nil {
+		if err == ErrInvalidJSON {
+			return nil, err
+		}
+		segment := s.deepest
+		if segment >= len(p.elements) {
+			segment = len(p.elements) - 1
+		}
+		return nil, p.errorAt(segment, p.elements[segment].expects(), s.at, err)
+	}
+	return data[begin:end], nil
+}
+
+// GetBytesInt is used to get the integer at this path, directly from the json data.
+func (p *Path) GetBytesInt(data []byte) (int, error) {
+	raw, err := p.GetBytes(data)
+	if err != nil {
+		return 0, err
+	}
+	i, err := rawInt64(raw, strconv.IntSize)
+	return int(i), p.rawTypeError(err, raw, KindNumber)
+}
+
+// GetBytesInt64 is used to get the 64-bit integer at this path, directly from the json data.
+func (p *Path) GetBytesInt64(data []byte) (int64, error) {
+	raw, err := p.GetBytes(data)
+	if err != nil {
+		return 0, err
+	}
+	i, err := rawInt64(raw, 64)
+	return i, p.rawTypeError(err, raw, KindNumber)
+}
+
+// GetBytesFloat64 is used to get the 64-bit floating point number at this path, directly from the json data.
+func (p *Path) GetBytesFloat64(data []byte) (float64, error) {
+	raw, err := p.GetBytes(data)
+	if err != nil {
+		return 0, err
+	}
+	if rawKind(raw) != KindNumber {
+		return 0, p.rawTypeError(ErrInvalidType, raw, KindNumber)
+	}
+	f, err := strconv.ParseFloat(string(raw), 64)
+	if err != nil {
+		return 0, p.rawTypeError(ErrOverflow, raw, KindNumber)
+	}
+	return f, nil
+}
+
+// GetBytesBool is used to get the boolean at this path, directly from the json data.
+func (p *Path) GetBytesBool(data []byte) (bool, error) {
+	raw, err := p.GetBytes(data)
+	if err != nil {
+		return false, err
+	}
+	switch string(raw) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return false, p.rawTypeError(ErrInvalidType, raw, KindBool)
+}
+
+// GetBytesString is used to get the string at this path, directly from the json data.
+func (p *Path) GetBytesString(data []byte) (string, error) {
+	raw, err := p.GetBytes(data)
+	if err != nil {
+		return empty, err
+	}
+	if rawKind(raw) != KindString {
+		return empty, p.rawTypeError(ErrInvalidType, raw, KindString)
+	}
+	return unquote(raw), nil
+}
+
+// singular reports whether the path can select at most a single json tree.
+func (p *Path) singular() bool {
+	for _, element := range p.elements {
+		if !element.singular() {
+			return false
+		}
+	}
+	return true
+}
+
+// getBytesFromTree resolves the path using a json tree in the lazy mode.
+func (p *Path) getBytesFromTree(data []byte) ([]byte, error) {
+	// the numbers are kept as they are, so that they are encoded back exactly
+	j, err := NewWithOptions(data, Lazy(), UseNumber())
+	if err != nil {
+		return nil, ErrInvalidJSON
+	}
+	child, err := p.Child(j)
+	if err != nil {
+		return nil, err
+	}
+	if raw, ok := child.data.(rawValue); ok {
+		return bytes.Trim(raw, " \t\r\n"), nil
+	}
+	return child.Bytes()
+}
+
+func (p *Path) rawTypeError(err error, raw []byte, expected Kind) error {
+	if err == nil {
+		return nil
+	}
+	return p.errorAt(len(p.elements)-1, expected, rawKind(raw), err)
+}
+
+// find returns the beginning and the end of the value at the path, in the value beginning at start,
+// which is contained in as many objects and arrays as the depth.
+// The errors are the same as the ones returned while resolving the path in the json tree.
+func (s *rawScanner) find(start int, path []pathElement, segment, depth int) (int, int, error) {
+	if start >= len(s.data) {
+		return 0, 0, ErrInvalidJSON
+	}
+	if segment > s.deepest {
+		s.deepest, s.at = segment, rawKind(s.data[start:])
+	}
+	if len(path) == 0 {
+		end, ok := skipValue(s.data, start, depth, true)
+		if !ok {
+			return 0, 0, ErrInvalidJSON
+		}
+		return start, end, nil
+	}
+	switch s.data[start] {
+	case '[':
+		if depth >= maxDepth {
+			return 0, 0, ErrInvalidJSON
+		}
+		return s.fromArray(start, path, segment, depth+1)
+	case '{':
+		if depth >= maxDepth {
+			return 0, 0, ErrInvalidJSON
+		}
+		return s.fromObject(start, path, segment, depth+1)
+	}
+	if _, ok := skipValue(s.data, start, depth, true); !ok {
+		return 0, 0, ErrInvalidJSON
+	}
+	return 0, 0, ErrUnexpectedJSONData
+}
+
+// fromArray finds the value at the path in the array beginning at start, where the depth includes the array.
+func (s *rawScanner) fromArray(start int, path []pathElement, segment, depth int) (int, int, error) {
+	index, err := path[0].arrayIndex()
+	if err != nil {
+		return 0, 0, err
+	}
+	if index < 0 {
+		// counted from the end of the array
+		length, err := s.length(start, depth)
+		if err != nil {
+			return 0, 0, err
+		}
+		index += length
+		if index < 0 {
+			return 0, 0, ErrIndexOutOfBound
+		}
+	}
+	i := skipSpace(s.data, start+1)
+	if i < len(s.data) && s.data[i] == ']' {
+		return 0, 0, ErrIndexOutOfBound
+	}
+	for n := 0; ; n++ {
+		if n == index {
+			return s.find(i, path[1:], segment+1, depth)
+		}
+		end, ok := skipValue(s.data, i, depth, true)
+		if !ok {
+			return 0, 0, ErrInvalidJSON
+		}
+		i = skipSpace(s.data, end)
+		if i >= len(s.data) {
+			return 0, 0, ErrInvalidJSON
+		}
+		switch s.data[i] {
+		case ']':
+			return 0, 0, ErrIndexOutOfBound
+		case ',':
+			i = skipSpace(s.data, i+1)
+		default:
+			return 0, 0, ErrInvalidJSON
+		}
+	}
+}
+
+// length returns the number of the elements in the array beginning at start.
+func (s *rawScanner) length(start, depth int) (int, error) {
+	i := skipSpace(s.data, start+1)
+	if i < len(s.data) && s.data[i] == ']' {
+		return 0, nil
+	}
+	for n := 1; ; n++ {
+		end, ok := skipValue(s.data, i, depth, true)
+		if !ok {
+			return 0, ErrInvalidJSON
+		}
+		i = skipSpace(s.data, end)
+		if i >= len(s.data) {
+			return 0, ErrInvalidJSON
+		}
+		switch s.data[i] {
+		case ']':
+			return n, nil
+		case ',':
+			i = skipSpace(s.data, i+1)
+		default:
+			return 0, ErrInvalidJSON
+		}
+	}
+}
+
+// fromObject tries the keys formed by joining the path elements, in the same order as nextCandidate,
+// in the object beginning at start, where the depth includes the object.
+func (s *rawScanner) fromObject(start int, path []pathElement, segment, depth int) (int, int, error) {
+	for n, p := range path {
+		if n > 0 && !p.joinable() {
+			break
+		}
+		value, found, err := s.lookup(start, path, n, depth)
+		if err != nil {
+			return 0, 0, err
+		}
+		if found {
+			begin, end, err := s.find(value, path[n+1:], segment+n+1, depth)
+			if err == nil || err == ErrInvalidJSON {
+				return begin, end, err
+			}
+		}
+		if !p.joinable() {
+			break
+		}
+	}
+	return 0, 0, ErrNoDataFound
+}
+
+// lookup returns the beginning of the value of the key formed by joining the first n+1 path elements,
+// in the object beginning at start. In case the key is repeated, the last one is used, same as
+// it is done while unmarshalling.
+func (s *rawScanner) lookup(start int, path []pathElement, n, depth int) (int, bool, error) {
+	value, found := 0, false
+	i := skipSpace(s.data, start+1)
+	if i < len(s.data) && s.data[i] == '}' {
+		return 0, false, nil
+	}
+	for {
+		if i >= len(s.data) || s.data[i] != '"' {
+			return 0, false, ErrInvalidJSON
+		}
+		end, ok := skipString(s.data, i)
+		if !ok {
+			return 0, false, ErrInvalidJSON
+		}
+		matched := matchKey(s.data[i:end], path, n)
+		i = skipSpace(s.data, end)
+		if i >= len(s.data) || s.data[i] != ':' {
+			return 0, false, ErrInvalidJSON
+		}
+		i = skipSpace(s.data, i+1)
+		if matched {
+			value, found = i, true
+		}
+		end, ok = skipValue(s.data, i, depth, true)
+		if !ok {
+			return 0, false, ErrInvalidJSON
+		}
+		i = skipSpace(s.data, end)
+		if i >= len(s.data) {
+			return 0, false, ErrInvalidJSON
+		}
+		switch s.data[i] {
+		case '}':
+			return value, found, nil
+		case ',':
+			i = skipSpace(s.data, i+1)
+		default:
+			return 0, false, ErrInvalidJSON
+		}
+	}
+}
+
+// value returns the whole value beginning at start.
+func (s *rawScanner) value(start int) ([]byte, error) {
+	end, ok := skipValue(s.data, start, 0, true)
+	if !ok {
+		return nil, ErrInvalidJSON
+	}
+	return s.data[start:end], nil
+}
+
+// matchKey reports whether the quoted key is the same as the one formed by joining
+// the first n+1 path elements.
+func matchKey(quoted []byte, path []pathElement, n int) bool {
+	key := quoted[1 : len(quoted)-1]
+	if bytes.IndexByte(key, '\\') >= 0 || !utf8.Valid(key) {
+		// most of the keys fit in the buffer, so that nothing is allocated
+		var buf [64]byte
+		key = appendUnquoted(buf[:0], key)
+	}
+	pos := 0
+	for i := 0; i <= n; i++ {
+		if i > 0 {
+			if !hasAt(key, pos, path[i].separator) {
+				return false
+			}
+			pos += len(path[i].separator)
+		}
+		if !hasAt(key, pos, path[i].key) {
+			return false
+		}
+		pos += len(path[i].key)
+	}
+	return pos == len(key)
+}
+
+func hasAt(b []byte, pos int, s string) bool {
+	return len(b)-pos >= len(s) && string(b[pos:pos+len(s)]) == s
+}
+
+// rawInt64 converts the raw json number to an integer of the bit size provided,
+// in the same way as it is done by toInt64.
+func rawInt64(raw []byte, bitSize int) (int64, error) {
+	if rawKind(raw) != KindNumber {
+		return 0, ErrInvalidType
+	}
+	return literalToInt64(string(raw), bitSize)
+}
@@ -0,0 +1,156 @@
+package jsonic_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBytesSameAsTree(t *testing.T) {
+	cases := map[string][]string{
+		"test_data/test1.json": {".", "", "a", "a.x", "a.arr[0].c.d.e", "a.x.y", "a.x.y.z", "c", "a.arr[-1].a",
+			"x", "a.arr[1]", "a.arr.x", "c.d", "a[\"x\"]", "a\\.x.y"},
+		"test_data/test2.json": {"a", "b", "c", "d", "e", "e[1]", "e[-2]", "e[-3]", "e[2]", "e.1", "f", "h[0]",
+			"i", "l.naruto", "i.x", "items[*]", "h[*]", "..naruto", "e[0:1]"},
+		"test_data/test3.json": {".", ""},
+		"test_data/test8.json": {"/a~1b/m~0n", "/a~1b/~01", "//", "/~1x", "/arr/2/k", "/arr/3", "/arr/-1",
+			"/arr/01", "/x", "arr[2].k", "\\/x"},
+		"test_data/test14.json": {"a.b.c", "a.b.d", "a.b", "dup", "dup.x", "escaped", "quo\"te", "arr[0][1]",
+			"arr[1].k[2]", "arr[-1]", "arr[3]", "big", "huge", "empty", "none", "none[0]", "text", "a.b.c.d",
+			"arr[2].x", "arr[?(@ == 's')]"},
+	}
+	for file, paths := range cases {
+		data := readFromFile(file, t)
+		j, err := jsonic.New(data)
+		assert.NoError(t, err)
+		for _, path := range paths {
+			expected, expectedErr := j.Get(path)
+			raw, err := jsonic.GetBytes(data, path)
+			if expectedErr != nil {
+				assert.Error(t, err, file+" "+path)
+				assert.Equal(t, expectedErr.Error(), err.Error(), file+" "+path)
+				continue
+			}
+			if !assert.NoError(t, err, file+" "+path) {
+				continue
+			}
+			var actual interface{}
+			assert.NoError(t, json.Unmarshal(raw, &actual), file+" "+path)
+			assert.Equal(t, expected, actual, file+" "+path)
+		}
+	}
+}
+
+func TestGetBytesTyped(t *testing.T) {
+	data := readFromFile("test_data/test14.json", t)
+
+	raw, err := jsonic.GetBytes(data, "arr[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"k": [true, false, null]}`, string(raw))
+
+	i, err := jsonic.GetBytesInt(data, "a.b.c")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+	i64, err := jsonic.GetBytesInt64(data, "big")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), i64)
+	i64, err = jsonic.GetBytesInt64(data, "arr[-1]")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-2500), i64)
+	_, err = jsonic.GetBytesInt(data, "huge")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	f, err := jsonic.GetBytesFloat64(data, "arr[-1]")
+	assert.NoError(t, err)
+	assert.Equal(t, -2500.0, f)
+	b, err := jsonic.GetBytesBool(data, "arr[1].k[1]")
+	assert.NoError(t, err)
+	assert.False(t, b)
+	s, err := jsonic.GetBytesString(data, "text")
+	assert.NoError(t, err)
+	assert.Equal(t, "na\nruto é", s)
+
+	_, err = jsonic.GetBytesString(data, "a.b.c")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	var pathErr *jsonic.PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, jsonic.KindString, pathErr.Expected)
+	assert.Equal(t, jsonic.KindNumber, pathErr.Actual)
+	_, err = jsonic.GetBytesBool(data, "text")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	_, err = jsonic.GetBytesFloat64(data, "x")
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+
+	p := jsonic.MustCompile("arr[0][1]")
+	i, err = p.GetBytesInt(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, i)
+}
+
+func TestGetBytesInvalid(t *testing.T) {
+	for _, data := range []string{``, `{"a": }`, `{"b": 1, "a": [1, 2}`, `{"a": [1, 2`, `{"a" 1}`, `{"b": x, "a": 1}`} {
+		_, err := jsonic.GetBytes([]byte(data), "a[1]")
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidJSON), data)
+	}
+	_, err := jsonic.GetBytes([]byte(`{"a": [`), "a[*]")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidJSON))
+	_, err = jsonic.GetBytes([]byte(`{}`), "a[\"")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+}
+
+func TestGetBytesDepth(t *testing.T) {
+	// as deep as allowed by encoding/json
+	data := []byte(strings.Repeat("[", 10000) + "1" + strings.Repeat("]", 10000))
+	b, err := jsonic.GetBytes(data, "[0][0]")
+	assert.NoError(t, err)
+	assert.Equal(t, string(data[2:len(data)-2]), string(b))
+	i, err := jsonic.GetBytesInt(data, strings.Repeat("[0]", 10000))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	deep := "[" + string(data) + "]"
+	for _, path := range []string{".", "[0]", strings.Repeat("[0]", 10000), "[*]", "[1]"} {
+		_, err = jsonic.GetBytes([]byte(deep), path)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidJSON), path)
+	}
+	deep = strings.Repeat(`{"a":[`, 10000000)
+	for _, path := range []string{".", "a[0].a", "a[-1]", "a[*]", "b"} {
+		_, err = jsonic.GetBytes([]byte(deep), path)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidJSON), path)
+	}
+}
+
+func TestGetBytesAllocations(t *testing.T) {
+	data := readFromFile("test_data/test14.json", t)
+	p := jsonic.MustCompile("arr[-1]")
+	q := jsonic.MustCompile("arr[1].k[0]")
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = p.GetBytes(data)
+		_, _ = p.GetBytesInt64(data)
+		_, _ = p.GetBytesFloat64(data)
+		_, _ = q.GetBytesBool(data)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func BenchmarkGetBytes(b *testing.B) {
+	data := largeJSON()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = jsonic.GetBytesString(data, "meta.id")
+	}
+}
+
+func BenchmarkGetBytesCompiled(b *testing.B) {
+	data := largeJSON()
+	p := jsonic.MustCompile("items[5000].price")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = p.GetBytes(data)
+	}
+}
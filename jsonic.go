@@ -1,7 +1,6 @@
 package jsonic
 
 import (
-	"encoding/json"
 	"strconv"
 	"strings"
 	"sync"
@@ -9,26 +8,42 @@ import (
 
 // Jsonic is the type to hold the JSON data
 type Jsonic struct {
-	data  interface{}
-	mu    *sync.RWMutex
-	cache map[string]*Jsonic
+	data   interface{}
+	mu     *sync.RWMutex
+	cache  map[string]*Jsonic
+	parent *Jsonic
+	key    string
+	opts   *options
 }
 
 type pathElement struct {
-	nature int
-	key    string
-	index  int
+	nature    int
+	key       string
+	index     int
+	separator string
+	exact     bool
+	slice     *slice
+	filter    jpLogical
 }
 
 // New is used to crete a new parser for the JSON data
 func New(data []byte) (*Jsonic, error) {
-	var unmarshalled interface{}
-	err := json.Unmarshal(data, &unmarshalled)
+	return NewWithOptions(data)
+}
+
+// NewWithOptions is used to create a new parser for the JSON data,
+// configured with the options provided.
+func NewWithOptions(data []byte, opts ...Option) (*Jsonic, error) {
+	o := newOptions(opts)
+	if o.lazy && isValid(data, o.useNumber) {
+		return new(rawValue(data), o), nil
+	}
+	unmarshalled, err := o.unmarshal(data)
 	if err != nil {
 		// not a valid json
 		return nil, err
 	}
-	return new(unmarshalled), nil
+	return new(unmarshalled, o), nil
 }
 
 // Child returns the json tree at the path specified.
@@ -36,421 +51,341 @@ func New(data []byte) (*Jsonic, error) {
 // It returns an error in case there is nothing that can be resolved
 // at the specified path.
 //
-// Path should be like this for example - a.[0].b, [0].a.b, etc.
+// Path should be like this for example - a.b[0].c, [0][1].a, a.[0].b, etc.
 // The path elements should be separated with dots.
 // Now the path elements can either be the index in case of an array
 // with the index enclosed within square brackets or it can be
-// the key of the object.
+// the key of the object. The indices can directly follow the key or
+// another index, and a negative index is counted from the end of the array.
+//
+// To refer to a key containing the special characters exactly, either escape
+// them with a backslash like a\.b, or quote the key like a["b.c"].
+//
+// In case the path selects multiple json trees, like the ones with wildcards
+// supported by Query, the first of them is returned.
+//
+// A path beginning with a slash is a json pointer, resolved as done in
+// ChildPointer. To refer to a key beginning with a slash, escape it like \/a.
 func (j *Jsonic) Child(path string) (*Jsonic, error) {
-	if path == dot || path == empty {
-		// this is a special case where we just need to check if the root
-		// has a dot as key or empty as key
-		return j.getDotOrEmptyChild(path), nil
-	}
-	return j.child(strings.Split(path, dot))
+	return compilePath(path).Child(j)
 }
 
 // Get is used to get the data at the path specified.
 func (j *Jsonic) Get(path string) (interface{}, error) {
-	child, err := j.Child(path)
-	if err != nil {
-		return nil, err
-	}
-	return child.data, nil
+	return compilePath(path).Get(j)
 }
 
 // GetTyped is used to get the data at the path specified in the value provided.
 // this value can be of any type, but preferably use a struct
 // using it with primitives will return an error
 // note that here a pointer should be used as value
+//
+// The data is set directly in the value, following the same rules as json.Unmarshal,
+// including the struct tags, the embedded structs, and the json.Unmarshaler and
+// encoding.TextUnmarshaler implementations. In case some data does not match the type,
+// the rest is still set, and the first of such errors is returned, naming the field.
 func (j *Jsonic) GetTyped(path string, val interface{}) error {
-	child, err := j.Child(path)
-	if err != nil {
-		return err
-	}
-	return child.parseInto(val)
+	return compilePath(path).GetTyped(j, val)
 }
 
 // GetInt is used to get the integer at the path specified.
+//
+// It returns an error in case the number does not fit in an int, and
+// ErrPrecision in case it has a fractional part, instead of truncating it.
 func (j *Jsonic) GetInt(path string) (int, error) {
-	val, err := j.Get(path)
-	if err != nil {
-		return 0, err
-	}
-	if i, ok := val.(float64); ok {
-		return int(i), nil
-	}
-	return 0, ErrInvalidType
+	return compilePath(path).GetInt(j)
 }
 
 // GetInt64 is used to get the integer at the path specified.
+//
+// It returns an error in case the number does not fit in an int64, and
+// ErrPrecision in case it has a fractional part, instead of truncating it.
+// Note that the integers beyond 2^53 can only be retrieved exactly
+// when the numbers are parsed using the UseNumber option.
 func (j *Jsonic) GetInt64(path string) (int64, error) {
-	val, err := j.Get(path)
-	if err != nil {
-		return 0, err
-	}
-	if i, ok := val.(float64); ok {
-		return int64(i), nil
-	}
-	return 0, ErrInvalidType
+	return compilePath(path).GetInt64(j)
 }
 
 // GetFloat is used to get the floating point number at the path specified.
+//
+// It returns an error in case the number does not fit in a float32.
 func (j *Jsonic) GetFloat(path string) (float32, error) {
-	val, err := j.Get(path)
-	if err != nil {
-		return 0, err
-	}
-	if f, ok := val.(float64); ok {
-		return float32(f), nil
-	}
-	return 0, ErrInvalidType
+	return compilePath(path).GetFloat(j)
 }
 
 // GetFloat64 is used to get the floating point number at the path specified.
+//
+// It returns an error in case the number does not fit in a float64.
 func (j *Jsonic) GetFloat64(path string) (float64, error) {
-	val, err := j.Get(path)
-	if err != nil {
-		return 0, err
-	}
-	if f, ok := val.(float64); ok {
-		return f, nil
-	}
-	return 0, ErrInvalidType
+	return compilePath(path).GetFloat64(j)
 }
 
-// GetBool is used to get the integer at the path specified.
+// GetBool is used to get the boolean at the path specified.
 func (j *Jsonic) GetBool(path string) (bool, error) {
-	val, err := j.Get(path)
-	if err != nil {
-		return false, err
-	}
-	if b, ok := val.(bool); ok {
-		return b, nil
-	}
-	return false, ErrInvalidType
+	return compilePath(path).GetBool(j)
 }
 
 // GetString is used to get the string at the path specified.
 func (j *Jsonic) GetString(path string) (string, error) {
-	val, err := j.Get(path)
-	if err != nil {
-		return "", err
-	}
-	if s, ok := val.(string); ok {
-		return s, nil
-	}
-	return "", ErrInvalidType
+	return compilePath(path).GetString(j)
 }
 
 // GetArray is used to get the data array at the path specified.
 func (j *Jsonic) GetArray(path string) ([]interface{}, error) {
-	val, err := j.Get(path)
-	if err != nil {
-		return nil, err
-	}
-	if a, ok := val.([]interface{}); ok {
-		return a, nil
-	}
-	return nil, ErrInvalidType
+	return compilePath(path).GetArray(j)
 }
 
 // GetIntArray is used to get the integer array at the path specified.
 func (j *Jsonic) GetIntArray(path string) ([]int, error) {
-	val, err := j.GetArray(path)
-	if err != nil {
-		return nil, err
-	}
-	iArr := make([]int, len(val))
-	for index, v := range val {
-		if i, ok := v.(float64); ok {
-			iArr[index] = int(i)
-		}
-	}
-	return iArr, nil
+	return compilePath(path).GetIntArray(j)
 }
 
 // GetInt64Array is used to get the 64-bit integer array at the path specified.
 func (j *Jsonic) GetInt64Array(path string) ([]int64, error) {
-	val, err := j.GetArray(path)
-	if err != nil {
-		return nil, err
-	}
-	iArr := make([]int64, len(val))
-	for index, v := range val {
-		if i, ok := v.(float64); ok {
-			iArr[index] = int64(i)
-		}
-	}
-	return iArr, nil
+	return compilePath(path).GetInt64Array(j)
 }
 
 // GetFloatArray is used to get the floating point number array at the path specified.
 func (j *Jsonic) GetFloatArray(path string) ([]float32, error) {
-	val, err := j.GetArray(path)
-	if err != nil {
-		return nil, err
-	}
-	fArr := make([]float32, len(val))
-	for index, v := range val {
-		if f, ok := v.(float64); ok {
-			fArr[index] = float32(f)
-		}
-	}
-	return fArr, nil
+	return compilePath(path).GetFloatArray(j)
 }
 
 // GetFloat64Array is used to get the 64-bit floating point number array at the path specified.
 func (j *Jsonic) GetFloat64Array(path string) ([]float64, error) {
-	val, err := j.GetArray(path)
-	if err != nil {
-		return nil, err
-	}
-	fArr := make([]float64, len(val))
-	for index, v := range val {
-		if f, ok := v.(float64); ok {
-			fArr[index] = f
-		}
-	}
-	return fArr, nil
+	return compilePath(path).GetFloat64Array(j)
 }
 
 // GetBoolArray is used to get the boolean array at the path specified.
 func (j *Jsonic) GetBoolArray(path string) ([]bool, error) {
-	val, err := j.GetArray(path)
-	if err != nil {
-		return nil, err
-	}
-	bArr := make([]bool, len(val))
-	for index, v := range val {
-		if b, ok := v.(bool); ok {
-			bArr[index] = b
-		}
-	}
-	return bArr, nil
+	return compilePath(path).GetBoolArray(j)
 }
 
 // GetStringArray is used to get the string array at the path specified.
 func (j *Jsonic) GetStringArray(path string) ([]string, error) {
-	val, err := j.GetArray(path)
-	if err != nil {
-		return nil, err
-	}
-	sArr := make([]string, len(val))
-	for index, v := range val {
-		if s, ok := v.(string); ok {
-			sArr[index] = s
-		}
-	}
-	return sArr, nil
+	return compilePath(path).GetStringArray(j)
 }
 
 // GetMap is used to get the data map at the path specified.
 func (j *Jsonic) GetMap(path string) (map[string]interface{}, error) {
-	val, err := j.Get(path)
-	if err != nil {
-		return nil, err
-	}
-	if m, ok := val.(map[string]interface{}); ok {
-		return m, nil
-	}
-	return nil, ErrInvalidType
+	return compilePath(path).GetMap(j)
 }
 
 // GetIntMap is used to get the integer map at the path specified.
 func (j *Jsonic) GetIntMap(path string) (map[string]int, error) {
-	val, err := j.GetMap(path)
-	if err != nil {
-		return nil, err
-	}
-	iMap := make(map[string]int)
-	for k, v := range val {
-		if i, ok := v.(float64); ok {
-			iMap[k] = int(i)
-		}
-	}
-	return iMap, nil
+	return compilePath(path).GetIntMap(j)
 }
 
 // GetInt64Map is used to get the 64-bit integer map at the path specified.
 func (j *Jsonic) GetInt64Map(path string) (map[string]int64, error) {
-	val, err := j.GetMap(path)
-	if err != nil {
-		return nil, err
-	}
-	iMap := make(map[string]int64)
-	for k, v := range val {
-		if i, ok := v.(float64); ok {
-			iMap[k] = int64(i)
-		}
-	}
-	return iMap, nil
+	return compilePath(path).GetInt64Map(j)
 }
 
 // GetFloatMap is used to get the floating point number map at the path specified.
 func (j *Jsonic) GetFloatMap(path string) (map[string]float32, error) {
-	val, err := j.GetMap(path)
-	if err != nil {
-		return nil, err
-	}
-	fMap := make(map[string]float32)
-	for k, v := range val {
-		if f, ok := v.(float64); ok {
-			fMap[k] = float32(f)
-		}
-	}
-	return fMap, nil
+	return compilePath(path).GetFloatMap(j)
 }
 
 // GetFloat64Map is used to get the 64-bit floating point number map at the path specified.
 func (j *Jsonic) GetFloat64Map(path string) (map[string]float64, error) {
-	val, err := j.GetMap(path)
-	if err != nil {
-		return nil, err
-	}
-	fMap := make(map[string]float64)
-	for k, v := range val {
-		if f, ok := v.(float64); ok {
-			fMap[k] = f
-		}
-	}
-	return fMap, nil
+	return compilePath(path).GetFloat64Map(j)
 }
 
 // GetBoolMap is used to get the boolean map at the path specified.
 func (j *Jsonic) GetBoolMap(path string) (map[string]bool, error) {
-	val, err := j.GetMap(path)
-	if err != nil {
-		return nil, err
-	}
-	bMap := make(map[string]bool)
-	for k, v := range val {
-		if b, ok := v.(bool); ok {
-			bMap[k] = b
-		}
-	}
-	return bMap, nil
+	return compilePath(path).GetBoolMap(j)
 }
 
 // GetStringMap is used to get the string map at the path specified.
 func (j *Jsonic) GetStringMap(path string) (map[string]string, error) {
-	val, err := j.GetMap(path)
-	if err != nil {
-		return nil, err
-	}
-	sMap := make(map[string]string)
-	for k, v := range val {
-		if s, ok := v.(string); ok {
-			sMap[k] = s
-		}
-	}
-	return sMap, nil
+	return compilePath(path).GetStringMap(j)
 }
 
-func new(data interface{}) *Jsonic {
+func new(data interface{}, opts *options) *Jsonic {
 	return &Jsonic{
 		data:  data,
 		mu:    &sync.RWMutex{},
 		cache: make(map[string]*Jsonic),
+		opts:  opts,
 	}
 }
 
 func (j *Jsonic) getDotOrEmptyChild(path string) *Jsonic {
-	if object, ok := j.data.(map[string]interface{}); ok {
-		if cached := j.checkInCache(path); cached != nil {
-			return cached
-		}
+	if object, ok := j.shallow().(map[string]interface{}); ok {
 		if data, ok := object[path]; ok {
-			child := new(data)
-			j.saveInCache(path, child)
-			return child
+			return j.childAt(path, data)
 		}
 	}
 	// in any other scenario we just return the root
 	return j
 }
 
-func (j *Jsonic) childFromArray(array []interface{}, path []string) (*Jsonic, error) {
+func (j *Jsonic) childFromArray(array []interface{}, path []pathElement, limit int,
+	results []*Jsonic) ([]*Jsonic, error) {
 	// get the index, which should be there as the first path element
-	index, err := getIndex(path[0])
+	index, err := path[0].indexIn(len(array))
 	if err != nil {
-		// some problem with the index
-		return nil, ErrIndexNotFound
-	}
-	if index < 0 || index >= len(array) {
-		// index out of bound
-		return nil, ErrIndexOutOfBound
+		return results, err
 	}
-	// check in cache for the child
-	if cached := j.checkInCache(strconv.Itoa(index)); cached != nil {
-		return cached.child(path[1:])
+	// get the child from the cache, or create and save it
+	return j.childAt(strconv.Itoa(index), array[index]).children(path[1:], limit, results)
+}
+
+func (j *Jsonic) childFromObject(object map[string]interface{}, path []pathElement, limit int,
+	results []*Jsonic) ([]*Jsonic, error) {
+	for i := 0; i < len(path); i++ {
+		key, data, end, ok := nextCandidate(object, path, i)
+		if !ok {
+			break
+		}
+		count := len(results)
+		results, _ = j.childAt(key, data).children(path[end+1:], limit, results)
+		if len(results) > count {
+			// result found successfully
+			return results, nil
+		}
+		// nothing here, check further
+		i = end
 	}
-	// create a child, and save it
-	child := new(array[index])
-	j.saveInCache(strconv.Itoa(index), child)
-	return child.child(path[1:])
+	return results, ErrNoDataFound
 }
 
-func (j *Jsonic) childFromObject(object map[string]interface{}, path []string) (*Jsonic, error) {
-	current := ""
-	// this loop is to handle the following scenario
+// nextCandidate returns the first of the keys present in the object, formed by joining the path
+// elements, which ends at the path element with the index provided or after it, along with
+// the index of the path element it ends at.
+func nextCandidate(object map[string]interface{}, path []pathElement, from int) (string, interface{}, int, bool) {
+	// this is to handle the following scenario
 	// say the path elements are as follows a, b and c
 	// it might so happen that each of a, a.b and a.b.c are
 	// present in the json data as keys, so we should give
 	// each of them a fair chance. the only thing is we
 	// are giving preference in the following order a > a.b > a.b.c
+	// the elements are joined in the same way as they were in the path,
+	// so for a.b[0] the preference is a > a.b > a.b[0]
+	// an exact key is never joined with the other elements
+	var buffer [64]byte
+	joined := buffer[:0]
 	for i, p := range path {
-		current += p
-		if cached := j.checkInCache(current); cached != nil {
-			result, err := cached.child(path[i+1:])
-			if err == nil {
-				// result found successfully
-				return result, nil
+		if i > 0 {
+			if !p.joinable() {
+				break
+			}
+			joined = append(joined, p.separator...)
+		}
+		joined = append(joined, p.key...)
+		if i >= from {
+			// the lookup does not copy the joined key
+			if data, ok := object[string(joined)]; ok {
+				if i == 0 {
+					return p.key, data, i, true
+				}
+				return string(joined), data, i, true
 			}
-		} else if data, ok := object[current]; ok {
-			child := new(data)
-			j.saveInCache(current, child)
-			result, err := child.child(path[i+1:])
-			if err == nil {
-				// result found successfully
-				return result, nil
+		}
+		if !p.joinable() {
+			break
+		}
+	}
+	return empty, nil, 0, false
+}
+
+// failure returns the index of the path element at which the path could not be resolved,
+// along with the json tree it was resolved on, going as deep as possible in the json tree.
+// The index of the first path element is the one provided.
+func (j *Jsonic) failure(path []pathElement, segment int) (int, *Jsonic) {
+	deepest, at := segment, j
+	consider := func(s int, c *Jsonic) {
+		if s > deepest {
+			deepest, at = s, c
+		}
+	}
+	if len(path) == 0 {
+		return deepest, at
+	}
+	switch path[0].nature {
+	case natureWildcard, natureSlice, natureFilter:
+		for _, child := range j.selected(path[0]) {
+			consider(child.failure(path[1:], segment+1))
+		}
+		return deepest, at
+	case natureDescent:
+		for _, child := range j.selfAndDescendants(nil) {
+			consider(child.failure(path[1:], segment+1))
+		}
+		return deepest, at
+	}
+	switch data := j.shallow().(type) {
+	case []interface{}:
+		if index, err := path[0].indexIn(len(data)); err == nil {
+			consider(j.childAt(strconv.Itoa(index), data[index]).failure(path[1:], segment+1))
+		}
+	case map[string]interface{}:
+		for i := 0; i < len(path); i++ {
+			key, value, end, ok := nextCandidate(data, path, i)
+			if !ok {
+				break
 			}
+			consider(j.childAt(key, value).failure(path[end+1:], segment+end+1))
+			i = end
 		}
-		// nothing here, check further
-		current += dot
 	}
-	return nil, ErrNoDataFound
+	return deepest, at
+}
+
+func (j *Jsonic) child(path []pathElement) (*Jsonic, error) {
+	results, err := j.children(path, 1, nil)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
 }
 
-func (j *Jsonic) child(path []string) (*Jsonic, error) {
+// children adds the json trees at the path to the results, till the limit is reached.
+// A negative limit means there is no limit.
+//
+// It returns an error only when nothing could be resolved at the path.
+func (j *Jsonic) children(path []pathElement, limit int, results []*Jsonic) ([]*Jsonic, error) {
 	// first the base condition
 	if len(path) == 0 {
 		// we have reached the result
-		return j, nil
+		return append(results, j), nil
+	}
+	// the elements selecting multiple trees are handled separately
+	switch path[0].nature {
+	case natureWildcard, natureSlice, natureFilter:
+		return j.childrenFromSelected(path, limit, results)
+	case natureDescent:
+		return j.childrenFromDescendants(path[1:], limit, results)
 	}
 	// either data is array or object
 	// we need to check that
 	// and accordingly proceed
-	if array, ok := j.data.([]interface{}); ok {
-		return j.childFromArray(array, path)
+	if array, ok := j.shallow().([]interface{}); ok {
+		return j.childFromArray(array, path, limit, results)
 	}
-	if object, ok := j.data.(map[string]interface{}); ok {
-		return j.childFromObject(object, path)
+	if object, ok := j.shallow().(map[string]interface{}); ok {
+		return j.childFromObject(object, path, limit, results)
 	}
-	return nil, ErrUnexpectedJSONData
+	return results, ErrUnexpectedJSONData
 }
 
 func (j *Jsonic) parseInto(val interface{}) error {
-	b, err := json.Marshal(j.data)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(b, val)
+	return decode(j.value(), val)
 }
 
 func getIndex(element string) (int, error) {
 	// it should be enclosed within curly braces
-	return strconv.Atoi(strings.TrimPrefix(strings.TrimSuffix(element, closeBracket), openBracket))
+	return parseInt(strings.TrimPrefix(strings.TrimSuffix(element, closeBracket), openBracket))
+}
+
+func (j *Jsonic) childAt(key string, data interface{}) *Jsonic {
+	if cached := j.checkInCache(key); cached != nil {
+		return cached
+	}
+	child := new(data, j.opts)
+	child.parent = j
+	child.key = key
+	j.saveInCache(key, child)
+	return child
 }
 
 func (j *Jsonic) checkInCache(path string) *Jsonic {
@@ -464,3 +399,199 @@ func (j *Jsonic) saveInCache(path string, child *Jsonic) {
 	defer j.mu.Unlock()
 	j.cache[path] = child
 }
+
+func (j *Jsonic) removeFromCache(path string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if child, ok := j.cache[path]; ok {
+		// detach the child, so that it does not update this tree anymore
+		child.parent = nil
+		delete(j.cache, path)
+	}
+}
+
+func (j *Jsonic) resetCache() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, child := range j.cache {
+		child.parent = nil
+	}
+	j.cache = make(map[string]*Jsonic)
+}
+
+func intOf(val interface{}, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	i, err := toInt64(val, strconv.IntSize)
+	if err != nil {
+		return 0, err
+	}
+	return int(i), nil
+}
+
+func int64Of(val interface{}, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+	return toInt64(val, 64)
+}
+
+func floatOf(val interface{}, err error) (float32, error) {
+	if err != nil {
+		return 0, err
+	}
+	f, err := toFloat64(val, 32)
+	if err != nil {
+		return 0, err
+	}
+	return float32(f), nil
+}
+
+func float64Of(val interface{}, err error) (float64, error) {
+	if err != nil {
+		return 0, err
+	}
+	return toFloat64(val, 64)
+}
+
+func boolOf(val interface{}, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+	if b, ok := val.(bool); ok {
+		return b, nil
+	}
+	return false, ErrInvalidType
+}
+
+func stringOf(val interface{}, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if s, ok := val.(string); ok {
+		return s, nil
+	}
+	return "", ErrInvalidType
+}
+
+func arrayOf(val interface{}, err error) ([]interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	if a, ok := val.([]interface{}); ok {
+		return a, nil
+	}
+	return nil, ErrInvalidType
+}
+
+func intArrayOf(data interface{}, err error, o *options) ([]int, error) {
+	return typedArrayOf(data, err, o, KindNumber, toInt)
+}
+
+func int64ArrayOf(data interface{}, err error, o *options) ([]int64, error) {
+	return typedArrayOf(data, err, o, KindNumber, toInt64Of)
+}
+
+func floatArrayOf(data interface{}, err error, o *options) ([]float32, error) {
+	return typedArrayOf(data, err, o, KindNumber, toFloat32)
+}
+
+func float64ArrayOf(data interface{}, err error, o *options) ([]float64, error) {
+	return typedArrayOf(data, err, o, KindNumber, toFloat64Of)
+}
+
+func boolArrayOf(data interface{}, err error, o *options) ([]bool, error) {
+	return typedArrayOf(data, err, o, KindBool, toBool)
+}
+
+func stringArrayOf(data interface{}, err error, o *options) ([]string, error) {
+	return typedArrayOf(data, err, o, KindString, toString)
+}
+
+// typedArrayOf converts each of the elements of the array using the function provided.
+func typedArrayOf[T any](data interface{}, err error, o *options, expected Kind,
+	convert func(interface{}) (T, error)) ([]T, error) {
+	val, err := arrayOf(data, err)
+	if err != nil {
+		return nil, err
+	}
+	arr := make([]T, 0, len(val))
+	for index, v := range val {
+		t, err := convert(v)
+		if err != nil {
+			if o.failElement(err) {
+				return nil, newElementError("["+strconv.Itoa(index)+"]", v, expected, err)
+			}
+			if o.elements == elementsSkip {
+				continue
+			}
+		}
+		arr = append(arr, t)
+	}
+	return arr, nil
+}
+
+func mapOf(val interface{}, err error) (map[string]interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := val.(map[string]interface{}); ok {
+		return m, nil
+	}
+	return nil, ErrInvalidType
+}
+
+func intMapOf(data interface{}, err error, o *options) (map[string]int, error) {
+	return typedMapOf(data, err, o, KindNumber, toInt)
+}
+
+func int64MapOf(data interface{}, err error, o *options) (map[string]int64, error) {
+	return typedMapOf(data, err, o, KindNumber, toInt64Of)
+}
+
+func floatMapOf(data interface{}, err error, o *options) (map[string]float32, error) {
+	return typedMapOf(data, err, o, KindNumber, toFloat32)
+}
+
+func float64MapOf(data interface{}, err error, o *options) (map[string]float64, error) {
+	return typedMapOf(data, err, o, KindNumber, toFloat64Of)
+}
+
+func boolMapOf(data interface{}, err error, o *options) (map[string]bool, error) {
+	return typedMapOf(data, err, o, KindBool, toBool)
+}
+
+func stringMapOf(data interface{}, err error, o *options) (map[string]string, error) {
+	return typedMapOf(data, err, o, KindString, toString)
+}
+
+// typedMapOf converts each of the values of the object using the function provided.
+func typedMapOf[T any](data interface{}, err error, o *options, expected Kind,
+	convert func(interface{}) (T, error)) (map[string]T, error) {
+	val, err := mapOf(data, err)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]T)
+	for k, v := range val {
+		t, err := convert(v)
+		if err != nil {
+			if o.failElement(err) {
+				return nil, newElementError("["+strconv.Quote(k)+"]", v, expected, err)
+			}
+			// the keys are never added with the zero value
+			continue
+		}
+		m[k] = t
+	}
+	return m, nil
+}
+
+// the conversions of the elements of the typed arrays and maps
+func toInt(v interface{}) (int, error)           { return intOf(v, nil) }
+func toInt64Of(v interface{}) (int64, error)     { return int64Of(v, nil) }
+func toFloat32(v interface{}) (float32, error)   { return floatOf(v, nil) }
+func toFloat64Of(v interface{}) (float64, error) { return float64Of(v, nil) }
+func toBool(v interface{}) (bool, error)         { return boolOf(v, nil) }
+func toString(v interface{}) (string, error)     { return stringOf(v, nil) }
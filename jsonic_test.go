@@ -1,6 +1,7 @@
 package jsonic_test
 
 import (
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -50,7 +51,7 @@ func TestChild(t *testing.T) {
 	c3, err := j.Child("a.m")
 	assert.Error(t, err)
 	assert.Nil(t, c3)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	// array
 	c4, err := j.Child("a.arr")
@@ -73,13 +74,13 @@ func TestChild(t *testing.T) {
 	c6, err := j.Child("a.arr.xyz")
 	assert.Error(t, err)
 	assert.Nil(t, c6)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	// array index out of bound
 	c7, err := j.Child("a.arr.[1]")
 	assert.Error(t, err)
 	assert.Nil(t, c7)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 }
 
 func TestGet(t *testing.T) {
@@ -107,7 +108,7 @@ func TestTyped(t *testing.T) {
 	i, err := j.GetInt("z")
 	assert.Equal(t, 0, i)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	i, err = j.GetInt("a")
 	assert.Equal(t, 1, i)
@@ -116,12 +117,12 @@ func TestTyped(t *testing.T) {
 	i, err = j.GetInt("e")
 	assert.Equal(t, 0, i)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	i64, err := j.GetInt64("z")
 	assert.Equal(t, int64(0), i64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	i64, err = j.GetInt64("a")
 	assert.Equal(t, int64(1), i64)
@@ -130,12 +131,12 @@ func TestTyped(t *testing.T) {
 	i64, err = j.GetInt64("e")
 	assert.Equal(t, int64(0), i64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	f, err := j.GetFloat("z")
 	assert.Equal(t, float32(0.0), f)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	f, err = j.GetFloat("b")
 	assert.Equal(t, float32(2.2), f)
@@ -144,12 +145,12 @@ func TestTyped(t *testing.T) {
 	f, err = j.GetFloat("e")
 	assert.Equal(t, float32(0.0), f)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	f64, err := j.GetFloat64("z")
 	assert.Equal(t, 0.0, f64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	f64, err = j.GetFloat64("b")
 	assert.Equal(t, 2.2, f64)
@@ -158,12 +159,12 @@ func TestTyped(t *testing.T) {
 	f64, err = j.GetFloat64("e")
 	assert.Equal(t, 0.0, f64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	b, err := j.GetBool("z")
 	assert.Equal(t, false, b)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	b, err = j.GetBool("c")
 	assert.Equal(t, true, b)
@@ -172,12 +173,12 @@ func TestTyped(t *testing.T) {
 	b, err = j.GetBool("e")
 	assert.Equal(t, false, b)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	s, err := j.GetString("z")
 	assert.Equal(t, "", s)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	s, err = j.GetString("d")
 	assert.Equal(t, "naruto", s)
@@ -186,7 +187,7 @@ func TestTyped(t *testing.T) {
 	s, err = j.GetString("e")
 	assert.Equal(t, "", s)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 }
 
 func TestTypedArray(t *testing.T) {
@@ -197,7 +198,7 @@ func TestTypedArray(t *testing.T) {
 	i, err := j.GetIntArray("z")
 	assert.Nil(t, i)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	i, err = j.GetIntArray("e")
 	assert.Equal(t, []int{1, 2}, i)
@@ -206,12 +207,12 @@ func TestTypedArray(t *testing.T) {
 	i, err = j.GetIntArray("a")
 	assert.Nil(t, i)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	i64, err := j.GetInt64Array("z")
 	assert.Nil(t, i64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	i64, err = j.GetInt64Array("e")
 	assert.Equal(t, []int64{1, 2}, i64)
@@ -220,12 +221,12 @@ func TestTypedArray(t *testing.T) {
 	i64, err = j.GetInt64Array("a")
 	assert.Nil(t, i64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	f, err := j.GetFloatArray("z")
 	assert.Nil(t, f)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	f, err = j.GetFloatArray("f")
 	assert.Equal(t, []float32{1.1, 2.2}, f)
@@ -234,12 +235,12 @@ func TestTypedArray(t *testing.T) {
 	f, err = j.GetFloatArray("a")
 	assert.Nil(t, f)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	f64, err := j.GetFloat64Array("z")
 	assert.Nil(t, f64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	f64, err = j.GetFloat64Array("f")
 	assert.Equal(t, []float64{1.1, 2.2}, f64)
@@ -248,12 +249,12 @@ func TestTypedArray(t *testing.T) {
 	f64, err = j.GetFloat64Array("a")
 	assert.Nil(t, f64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	b, err := j.GetBoolArray("z")
 	assert.Nil(t, b)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	b, err = j.GetBoolArray("g")
 	assert.Equal(t, []bool{true, false}, b)
@@ -262,12 +263,12 @@ func TestTypedArray(t *testing.T) {
 	b, err = j.GetBoolArray("a")
 	assert.Nil(t, b)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	s, err := j.GetStringArray("z")
 	assert.Nil(t, s)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	s, err = j.GetStringArray("h")
 	assert.Equal(t, []string{"naruto", "boruto"}, s)
@@ -276,7 +277,7 @@ func TestTypedArray(t *testing.T) {
 	s, err = j.GetStringArray("a")
 	assert.Nil(t, s)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 }
 
 func TestTypedMap(t *testing.T) {
@@ -287,7 +288,7 @@ func TestTypedMap(t *testing.T) {
 	i, err := j.GetIntMap("z")
 	assert.Nil(t, i)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	i, err = j.GetIntMap("i")
 	assert.Equal(t, map[string]int{"naruto": 1}, i)
@@ -296,12 +297,12 @@ func TestTypedMap(t *testing.T) {
 	i, err = j.GetIntMap("a")
 	assert.Nil(t, i)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	i64, err := j.GetInt64Map("z")
 	assert.Nil(t, i64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	i64, err = j.GetInt64Map("i")
 	assert.Equal(t, map[string]int64{"naruto": 1}, i64)
@@ -310,12 +311,12 @@ func TestTypedMap(t *testing.T) {
 	i64, err = j.GetInt64Map("a")
 	assert.Nil(t, i64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	f, err := j.GetFloatMap("z")
 	assert.Nil(t, f)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	f, err = j.GetFloatMap("j")
 	assert.Equal(t, map[string]float32{"naruto": 1.1}, f)
@@ -324,12 +325,12 @@ func TestTypedMap(t *testing.T) {
 	f, err = j.GetFloatMap("a")
 	assert.Nil(t, f)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	f64, err := j.GetFloat64Map("z")
 	assert.Nil(t, f64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	f64, err = j.GetFloat64Map("j")
 	assert.Equal(t, map[string]float64{"naruto": 1.1}, f64)
@@ -338,12 +339,12 @@ func TestTypedMap(t *testing.T) {
 	f64, err = j.GetFloat64Map("a")
 	assert.Nil(t, f64)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	b, err := j.GetBoolMap("z")
 	assert.Nil(t, b)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	b, err = j.GetBoolMap("k")
 	assert.Equal(t, map[string]bool{"naruto": true}, b)
@@ -352,12 +353,12 @@ func TestTypedMap(t *testing.T) {
 	b, err = j.GetBoolMap("a")
 	assert.Nil(t, b)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 
 	s, err := j.GetStringMap("z")
 	assert.Nil(t, s)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	s, err = j.GetStringMap("l")
 	assert.Equal(t, map[string]string{"naruto": "rocks"}, s)
@@ -366,7 +367,7 @@ func TestTypedMap(t *testing.T) {
 	s, err = j.GetStringMap("a")
 	assert.Nil(t, s)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrInvalidType, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
 }
 
 type TestTyped1 struct {
@@ -404,7 +405,7 @@ func TestGetTyped(t *testing.T) {
 	var t3 TestTyped1
 	err = j.GetTyped("z", &t3)
 	assert.Error(t, err)
-	assert.Equal(t, jsonic.ErrNoDataFound, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
 
 	// invalid type
 	var t4 TestTyped1
@@ -430,3 +431,34 @@ func TestDotOrEmptyChild(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "b", s)
 }
+
+func newForBenchmark(path string, b *testing.B) *jsonic.Jsonic {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	j, err := jsonic.New(data)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return j
+}
+
+func BenchmarkGetString(b *testing.B) {
+	j := newForBenchmark("test_data/test1.json", b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = j.GetString("a.arr[0].c.d.e")
+	}
+}
+
+func BenchmarkGetStringCompiled(b *testing.B) {
+	j := newForBenchmark("test_data/test1.json", b)
+	p := jsonic.MustCompile("a.arr[0].c.d.e")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = p.GetString(j)
+	}
+}
@@ -0,0 +1,1130 @@
+package jsonic
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// JSONPathQuery is the compiled JSONPath expression, as per RFC 9535.
+type JSONPathQuery struct {
+	expr     string
+	segments []jpSegment
+}
+
+// JSONPathError is returned when the JSONPath expression is not valid.
+type JSONPathError struct {
+	Expr   string
+	Offset int
+	Reason string
+}
+
+type jpSegment struct {
+	descendant bool
+	selectors  []jpSelector
+}
+
+// kinds of the selectors
+const (
+	jpName = iota
+	jpWildcard
+	jpIndex
+	jpSlice
+	jpFilter
+)
+
+type jpSelector struct {
+	kind   int
+	name   string
+	index  int
+	slice  *slice
+	filter jpLogical
+}
+
+// jpLogical is the logical expression used in the filter selectors.
+type jpLogical interface {
+	test(current, root *Jsonic) bool
+}
+
+type jpOr []jpLogical
+
+type jpAnd []jpLogical
+
+type jpNot struct {
+	expr jpLogical
+}
+
+type jpExists struct {
+	query *jpQuery
+}
+
+type jpTest struct {
+	call *jpCall
+}
+
+type jpComparison struct {
+	op    string
+	left  jpComparable
+	right jpComparable
+}
+
+// jpComparable is anything that can be compared in the filter selectors.
+// It returns nothing in case there is no value.
+type jpComparable interface {
+	value(current, root *Jsonic) interface{}
+}
+
+type jpLiteral struct {
+	data interface{}
+}
+
+// jpQuery is the query used inside the filter selectors.
+type jpQuery struct {
+	relative bool
+	singular bool
+	segments []jpSegment
+	// the filters inside the paths use the path instead of the segments
+	dotted bool
+	path   []pathElement
+}
+
+type jpCall struct {
+	function *jpFunction
+	args     []func(current, root *Jsonic) interface{}
+}
+
+// types of the function parameters and results
+const (
+	jpValueType = iota
+	jpLogicalType
+	jpNodesType
+)
+
+type jpFunction struct {
+	params []int
+	result int
+	call   func(args []interface{}) interface{}
+}
+
+// jpOperand is anything that can appear on either side of a comparison,
+// or as an argument of a function.
+type jpOperand struct {
+	literal bool
+	data    interface{}
+	query   *jpQuery
+	call    *jpCall
+}
+
+type jpNothing struct{}
+
+type jpParser struct {
+	expr      string
+	pos       int
+	functions map[string]*jpFunction
+	// dotted is set when parsing the filters inside the paths
+	dotted bool
+}
+
+// nothing is the absence of a value, which is different from null.
+var nothing = jpNothing{}
+
+// the limits of the integers as per I-JSON
+const (
+	jpMaxInt = 1<<53 - 1
+	jpMinInt = -jpMaxInt
+)
+
+var jpFunctions = map[string]*jpFunction{
+	"length": {params: []int{jpValueType}, result: jpValueType, call: jpLength},
+	"count":  {params: []int{jpNodesType}, result: jpValueType, call: jpCount},
+	"match":  {params: []int{jpValueType, jpValueType}, result: jpLogicalType, call: jpMatch},
+	"search": {params: []int{jpValueType, jpValueType}, result: jpLogicalType, call: jpSearch},
+	"value":  {params: []int{jpNodesType}, result: jpValueType, call: jpValue},
+}
+
+// jpMaxRegexps is the number of the regular expressions cached, as the patterns can
+// come from the json data queried, and so cannot be cached without a limit.
+const jpMaxRegexps = 256
+
+var jpRegexps = struct {
+	sync.Mutex
+	cache map[string]*regexp.Regexp
+}{cache: make(map[string]*regexp.Regexp)}
+
+// JSONPath compiles the JSONPath expression, as per RFC 9535.
+//
+// It supports the root identifier $, the child and the descendant segments,
+// the name, wildcard, index, slice and filter selectors, along with the
+// functions length, count, match, search and value in the filters.
+func JSONPath(expr string) (*JSONPathQuery, error) {
+	p := &jpParser{expr: expr, functions: jpFunctions}
+	if p.peek() != '$' {
+		return nil, p.errorf("expected $")
+	}
+	p.pos++
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.expr) {
+		return nil, p.errorf("unexpected character")
+	}
+	return &JSONPathQuery{expr: expr, segments: segments}, nil
+}
+
+// String returns the JSONPath expression.
+func (q *JSONPathQuery) String() string {
+	return q.expr
+}
+
+// Query returns the json trees selected by the JSONPath expression from the json tree provided,
+// which is used as the root.
+//
+// The results are in the order they are selected, with the keys of the
+// objects taken in the sorted order. The results are connected to the json
+// tree, so they are same as the ones returned by Child for the same location.
+func (q *JSONPathQuery) Query(j *Jsonic) []*Jsonic {
+	results := selectSegments(q.segments, []*Jsonic{j}, j)
+	if results == nil {
+		return make([]*Jsonic, 0)
+	}
+	return results
+}
+
+// QueryJSONPath returns the json trees selected by the JSONPath expression, as per RFC 9535.
+//
+// It returns an error in case the expression is not valid.
+func (j *Jsonic) QueryJSONPath(expr string) ([]*Jsonic, error) {
+	q, err := JSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.Query(j), nil
+}
+
+// Error returns the reason the expression is not valid.
+func (e *JSONPathError) Error() string {
+	return fmt.Sprintf("invalid jsonpath %q at offset %d: %s", e.Expr, e.Offset, e.Reason)
+}
+
+// Unwrap returns ErrInvalidPath, so that it can be checked using errors.Is.
+func (e *JSONPathError) Unwrap() error {
+	return ErrInvalidPath
+}
+
+func selectSegments(segments []jpSegment, nodes []*Jsonic, root *Jsonic) []*Jsonic {
+	for _, segment := range segments {
+		var selected []*Jsonic
+		for _, node := range nodes {
+			if segment.descendant {
+				for _, d := range node.selfAndDescendants(nil) {
+					selected = segment.selectFrom(d, root, selected)
+				}
+			} else {
+				selected = segment.selectFrom(node, root, selected)
+			}
+		}
+		nodes = selected
+	}
+	return nodes
+}
+
+// selfAndDescendants returns the json tree along with all its descendants,
+// each one preceding its own descendants.
+func (j *Jsonic) selfAndDescendants(results []*Jsonic) []*Jsonic {
+	results = append(results, j)
+	for _, child := range j.selected(pathElement{nature: natureWildcard}) {
+		results = child.selfAndDescendants(results)
+	}
+	return results
+}
+
+func (s jpSegment) selectFrom(node, root *Jsonic, selected []*Jsonic) []*Jsonic {
+	for _, selector := range s.selectors {
+		selected = selector.selectFrom(node, root, selected)
+	}
+	return selected
+}
+
+func (s jpSelector) selectFrom(node, root *Jsonic, selected []*Jsonic) []*Jsonic {
+	switch s.kind {
+	case jpName:
+		if object, ok := node.shallow().(map[string]interface{}); ok {
+			if data, ok := object[s.name]; ok {
+				selected = append(selected, node.childAt(s.name, data))
+			}
+		}
+	case jpWildcard:
+		selected = append(selected, node.selected(pathElement{nature: natureWildcard})...)
+	case jpIndex:
+		if array, ok := node.shallow().([]interface{}); ok {
+			index := s.index
+			if index < 0 {
+				index += len(array)
+			}
+			if index >= 0 && index < len(array) {
+				selected = append(selected, node.childAt(strconv.Itoa(index), array[index]))
+			}
+		}
+	case jpSlice:
+		selected = append(selected, node.selected(pathElement{nature: natureSlice, slice: s.slice})...)
+	case jpFilter:
+		for _, child := range node.selected(pathElement{nature: natureWildcard}) {
+			if s.filter.test(child, root) {
+				selected = append(selected, child)
+			}
+		}
+	}
+	return selected
+}
+
+func (e jpOr) test(current, root *Jsonic) bool {
+	for _, expr := range e {
+		if expr.test(current, root) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e jpAnd) test(current, root *Jsonic) bool {
+	for _, expr := range e {
+		if !expr.test(current, root) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e jpNot) test(current, root *Jsonic) bool {
+	return !e.expr.test(current, root)
+}
+
+func (e jpExists) test(current, root *Jsonic) bool {
+	return len(e.query.nodes(current, root)) > 0
+}
+
+func (e jpTest) test(current, root *Jsonic) bool {
+	switch result := e.call.evaluate(current, root).(type) {
+	case bool:
+		return result
+	case []*Jsonic:
+		return len(result) > 0
+	}
+	return false
+}
+
+func (e jpComparison) test(current, root *Jsonic) bool {
+	left, right := e.left.value(current, root), e.right.value(current, root)
+	switch e.op {
+	case "==":
+		return jpEqual(left, right)
+	case "!=":
+		return !jpEqual(left, right)
+	case "<":
+		return jpLess(left, right)
+	case "<=":
+		return jpLess(left, right) || jpEqual(left, right)
+	case ">":
+		return jpLess(right, left)
+	case ">=":
+		return jpLess(right, left) || jpEqual(left, right)
+	}
+	return false
+}
+
+func (l jpLiteral) value(*Jsonic, *Jsonic) interface{} {
+	return l.data
+}
+
+func (q *jpQuery) value(current, root *Jsonic) interface{} {
+	if q.dotted {
+		// same as the data returned by Get
+		child, err := current.child(q.path)
+		if err != nil {
+			return nothing
+		}
+		return child.value()
+	}
+	nodes := q.nodes(current, root)
+	if len(nodes) != 1 {
+		return nothing
+	}
+	return nodes[0].value()
+}
+
+func (q *jpQuery) nodes(current, root *Jsonic) []*Jsonic {
+	start := root
+	if q.relative {
+		start = current
+	}
+	if q.dotted {
+		return start.dottedNodes(q.path)
+	}
+	return selectSegments(q.segments, []*Jsonic{start}, root)
+}
+
+func (c *jpCall) value(current, root *Jsonic) interface{} {
+	return c.evaluate(current, root)
+}
+
+func (c *jpCall) evaluate(current, root *Jsonic) interface{} {
+	args := make([]interface{}, len(c.args))
+	for i, arg := range c.args {
+		args[i] = arg(current, root)
+	}
+	return c.function.call(args)
+}
+
+func jpEqual(a, b interface{}) bool {
+	if a == nothing || b == nothing {
+		return a == b
+	}
+	return equalValues(a, b)
+}
+
+func jpLess(a, b interface{}) bool {
+	if isNumber(a) && isNumber(b) {
+		return compareNumbers(a, b) < 0
+	}
+	x, okX := a.(string)
+	y, okY := b.(string)
+	// comparing the utf-8 bytes is same as comparing the unicode code points
+	return okX && okY && x < y
+}
+
+func jpLength(args []interface{}) interface{} {
+	switch v := args[0].(type) {
+	case string:
+		return float64(utf8.RuneCountInString(v))
+	case []interface{}:
+		return float64(len(v))
+	case map[string]interface{}:
+		return float64(len(v))
+	}
+	return nothing
+}
+
+func jpCount(args []interface{}) interface{} {
+	return float64(len(args[0].([]*Jsonic)))
+}
+
+func jpMatch(args []interface{}) interface{} {
+	return jpRegexpMatch(args, true)
+}
+
+func jpSearch(args []interface{}) interface{} {
+	return jpRegexpMatch(args, false)
+}
+
+func jpValue(args []interface{}) interface{} {
+	nodes := args[0].([]*Jsonic)
+	if len(nodes) != 1 {
+		return nothing
+	}
+	return nodes[0].value()
+}
+
+func jpRegexpMatch(args []interface{}, full bool) bool {
+	s, ok := args[0].(string)
+	if !ok {
+		return false
+	}
+	pattern, ok := args[1].(string)
+	if !ok {
+		return false
+	}
+	re := compileIRegexp(pattern, full)
+	return re != nil && re.MatchString(s)
+}
+
+// compileIRegexp compiles the I-Regexp as per RFC 9485, matching either the
+// entire string, or any substring of it. It returns nil if it is not valid.
+func compileIRegexp(pattern string, full bool) *regexp.Regexp {
+	key := strconv.FormatBool(full) + pattern
+	jpRegexps.Lock()
+	cached, ok := jpRegexps.cache[key]
+	jpRegexps.Unlock()
+	if ok {
+		return cached
+	}
+	// the dot does not match the line terminators in I-Regexp
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			b.WriteByte(c)
+			i++
+			c = pattern[i]
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case c == '.' && !inClass:
+			b.WriteString(`[^\n\r]`)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	converted := b.String()
+	if full {
+		converted = `\A(?:` + converted + `)\z`
+	}
+	re, err := regexp.Compile(converted)
+	if err != nil {
+		return nil
+	}
+	jpRegexps.Lock()
+	if len(jpRegexps.cache) >= jpMaxRegexps {
+		// the cache is started over, instead of keeping track of the ones used recently
+		jpRegexps.cache = make(map[string]*regexp.Regexp)
+	}
+	jpRegexps.cache[key] = re
+	jpRegexps.Unlock()
+	return re
+}
+
+func (p *jpParser) errorf(reason string) error {
+	return &JSONPathError{Expr: p.expr, Offset: p.pos, Reason: reason}
+}
+
+func (p *jpParser) peek() byte {
+	if p.pos < len(p.expr) {
+		return p.expr[p.pos]
+	}
+	return 0
+}
+
+func (p *jpParser) skipBlanks() {
+	for p.pos < len(p.expr) && isBlank(p.expr[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *jpParser) parseSegments() ([]jpSegment, error) {
+	var segments []jpSegment
+	for {
+		start := p.pos
+		p.skipBlanks()
+		if c := p.peek(); c != '.' && c != '[' {
+			// the blanks are not a part of the segments
+			p.pos = start
+			return segments, nil
+		}
+		segment, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+}
+
+func (p *jpParser) parseSegment() (jpSegment, error) {
+	segment := jpSegment{}
+	if p.peek() == '[' {
+		selectors, err := p.parseBracketed()
+		segment.selectors = selectors
+		return segment, err
+	}
+	p.pos++
+	if p.peek() == '.' {
+		p.pos++
+		segment.descendant = true
+		if p.peek() == '[' {
+			selectors, err := p.parseBracketed()
+			segment.selectors = selectors
+			return segment, err
+		}
+	}
+	if p.peek() == '*' {
+		p.pos++
+		segment.selectors = []jpSelector{{kind: jpWildcard}}
+		return segment, nil
+	}
+	name, err := p.parseMemberName()
+	segment.selectors = []jpSelector{{kind: jpName, name: name}}
+	return segment, err
+}
+
+func (p *jpParser) parseMemberName() (string, error) {
+	start := p.pos
+	for p.pos < len(p.expr) {
+		r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+		if r == utf8.RuneError && size == 1 {
+			break
+		}
+		if !(r == '_' || r >= utf8.RuneSelf || isAlpha(byte(r)) || (p.pos > start && isDigit(byte(r)))) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return empty, p.errorf("expected member name")
+	}
+	return p.expr[start:p.pos], nil
+}
+
+func (p *jpParser) parseBracketed() ([]jpSelector, error) {
+	// skip the opening bracket
+	p.pos++
+	var selectors []jpSelector
+	for {
+		p.skipBlanks()
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+		p.skipBlanks()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return selectors, nil
+		default:
+			return nil, p.errorf("expected , or ]")
+		}
+	}
+}
+
+func (p *jpParser) parseSelector() (jpSelector, error) {
+	switch c := p.peek(); c {
+	case '\'', '"':
+		name, err := p.parseString()
+		return jpSelector{kind: jpName, name: name}, err
+	case '*':
+		p.pos++
+		return jpSelector{kind: jpWildcard}, nil
+	case '?':
+		p.pos++
+		p.skipBlanks()
+		filter, err := p.parseLogical()
+		return jpSelector{kind: jpFilter, filter: filter}, err
+	}
+	start, hasStart, err := p.parseOptionalInt()
+	if err != nil {
+		return jpSelector{}, err
+	}
+	p.skipBlanks()
+	if p.peek() != ':' {
+		if !hasStart {
+			return jpSelector{}, p.errorf("expected selector")
+		}
+		return jpSelector{kind: jpIndex, index: start}, nil
+	}
+	s := &slice{step: 1}
+	if hasStart {
+		s.start = &start
+	}
+	p.pos++
+	p.skipBlanks()
+	end, hasEnd, err := p.parseOptionalInt()
+	if err != nil {
+		return jpSelector{}, err
+	}
+	if hasEnd {
+		s.end = &end
+	}
+	p.skipBlanks()
+	if p.peek() == ':' {
+		p.pos++
+		p.skipBlanks()
+		step, hasStep, err := p.parseOptionalInt()
+		if err != nil {
+			return jpSelector{}, err
+		}
+		if hasStep {
+			s.step = step
+		}
+	}
+	return jpSelector{kind: jpSlice, slice: s}, nil
+}
+
+func (p *jpParser) parseOptionalInt() (int, bool, error) {
+	if c := p.peek(); c != '-' && !isDigit(c) {
+		return 0, false, nil
+	}
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+	switch {
+	case p.pos == digits:
+		return 0, false, p.errorf("expected digits")
+	case p.expr[digits] == '0' && (p.pos-digits > 1 || digits > start):
+		return 0, false, p.errorf("leading zeros and negative zero are not allowed")
+	}
+	n, err := strconv.ParseInt(p.expr[start:p.pos], 10, 64)
+	if err != nil || n > jpMaxInt || n < jpMinInt {
+		return 0, false, p.errorf("integer out of range")
+	}
+	return int(n), true, nil
+}
+
+func (p *jpParser) parseString() (string, error) {
+	quote := p.expr[p.pos]
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.expr) {
+		c := p.expr[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c == '\\':
+			p.pos++
+			r, err := p.parseEscape(quote)
+			if err != nil {
+				return empty, err
+			}
+			b.WriteRune(r)
+		case c < 0x20:
+			return empty, p.errorf("control characters should be escaped")
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return empty, p.errorf("string not terminated")
+}
+
+func (p *jpParser) parseEscape(quote byte) (rune, error) {
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case '/', '\\', quote:
+		return rune(c), nil
+	case 'u':
+		r, err := p.parseHex()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case r >= 0xDC00 && r <= 0xDFFF:
+			return 0, p.errorf("unpaired low surrogate")
+		case r >= 0xD800 && r <= 0xDBFF:
+			// this should be followed by the low surrogate
+			if !strings.HasPrefix(p.expr[p.pos:], `\u`) {
+				return 0, p.errorf("unpaired high surrogate")
+			}
+			p.pos += 2
+			low, err := p.parseHex()
+			if err != nil {
+				return 0, err
+			}
+			if low < 0xDC00 || low > 0xDFFF {
+				return 0, p.errorf("unpaired high surrogate")
+			}
+			return (r-0xD800)<<10 + (low - 0xDC00) + 0x10000, nil
+		}
+		return r, nil
+	}
+	p.pos--
+	return 0, p.errorf("invalid escape")
+}
+
+func (p *jpParser) parseHex() (rune, error) {
+	if p.pos+4 > len(p.expr) {
+		return 0, p.errorf("expected 4 hexadecimal digits")
+	}
+	n, err := strconv.ParseUint(p.expr[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("expected 4 hexadecimal digits")
+	}
+	p.pos += 4
+	return rune(n), nil
+}
+
+func (p *jpParser) parseLogical() (jpLogical, error) {
+	var or jpOr
+	for {
+		var and jpAnd
+		for {
+			expr, err := p.parseBasic()
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, expr)
+			if !p.skipOperator("&&") {
+				break
+			}
+		}
+		if len(and) == 1 {
+			or = append(or, and[0])
+		} else {
+			or = append(or, and)
+		}
+		if !p.skipOperator("||") {
+			break
+		}
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+// skipOperator skips the operator along with the blanks around it, if it is present.
+func (p *jpParser) skipOperator(op string) bool {
+	start := p.pos
+	p.skipBlanks()
+	if !strings.HasPrefix(p.expr[p.pos:], op) {
+		p.pos = start
+		return false
+	}
+	p.pos += len(op)
+	p.skipBlanks()
+	return true
+}
+
+func (p *jpParser) parseBasic() (jpLogical, error) {
+	switch p.peek() {
+	case '!':
+		p.pos++
+		p.skipBlanks()
+		var expr jpLogical
+		var err error
+		if p.peek() == '(' {
+			expr, err = p.parseParen()
+		} else {
+			expr, err = p.parseTest()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return jpNot{expr: expr}, nil
+	case '(':
+		return p.parseParen()
+	}
+	start := p.pos
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	save := p.pos
+	p.skipBlanks()
+	if p.dotted && strings.HasPrefix(p.expr[p.pos:], regexMatch) {
+		return p.parseRegexMatch(left, start)
+	}
+	op := p.parseComparisonOperator()
+	if op == empty {
+		p.pos = save
+		if left.literal {
+			p.pos = start
+			return nil, p.errorf("literal should be compared")
+		}
+		return p.testOf(left, start)
+	}
+	p.skipBlanks()
+	rightStart := p.pos
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	l, err := p.comparableOf(left, start)
+	if err != nil {
+		return nil, err
+	}
+	r, err := p.comparableOf(right, rightStart)
+	if err != nil {
+		return nil, err
+	}
+	return jpComparison{op: op, left: l, right: r}, nil
+}
+
+func (p *jpParser) parseParen() (jpLogical, error) {
+	p.pos++
+	p.skipBlanks()
+	expr, err := p.parseLogical()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlanks()
+	if p.peek() != ')' {
+		return nil, p.errorf("expected )")
+	}
+	p.pos++
+	return expr, nil
+}
+
+func (p *jpParser) parseTest() (jpLogical, error) {
+	start := p.pos
+	operand, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if operand.literal {
+		p.pos = start
+		return nil, p.errorf("literal cannot be tested")
+	}
+	return p.testOf(operand, start)
+}
+
+func (p *jpParser) parseComparisonOperator() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.expr[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return empty
+}
+
+func (p *jpParser) parseOperand() (jpOperand, error) {
+	c := p.peek()
+	switch {
+	case c == '@' && p.dotted:
+		p.pos++
+		return p.parseDottedQuery()
+	case c == '@' || c == '$':
+		if p.dotted {
+			return jpOperand{}, p.errorf("only the relative paths are allowed")
+		}
+		p.pos++
+		segments, err := p.parseSegments()
+		if err != nil {
+			return jpOperand{}, err
+		}
+		return jpOperand{query: &jpQuery{relative: c == '@', singular: isSingular(segments), segments: segments}}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		return jpOperand{literal: true, data: s}, err
+	case c == '-' || isDigit(c):
+		n, err := p.parseNumber()
+		return jpOperand{literal: true, data: n}, err
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for c := p.peek(); (c >= 'a' && c <= 'z') || c == '_' || isDigit(c); c = p.peek() {
+			p.pos++
+		}
+		name := p.expr[start:p.pos]
+		if p.peek() == '(' {
+			call, err := p.parseCall(name, start)
+			return jpOperand{call: call}, err
+		}
+		switch name {
+		case "true":
+			return jpOperand{literal: true, data: true}, nil
+		case "false":
+			return jpOperand{literal: true, data: false}, nil
+		case "null":
+			return jpOperand{literal: true, data: nil}, nil
+		}
+		p.pos = start
+	}
+	return jpOperand{}, p.errorf("expected literal, query or function")
+}
+
+func (p *jpParser) parseNumber() (float64, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+	if p.pos == digits || (p.expr[digits] == '0' && p.pos-digits > 1) {
+		return 0, p.errorf("invalid number")
+	}
+	if p.peek() == '.' {
+		p.pos++
+		fraction := p.pos
+		for isDigit(p.peek()) {
+			p.pos++
+		}
+		if p.pos == fraction {
+			return 0, p.errorf("invalid number")
+		}
+	}
+	if c := p.peek(); c == 'e' || c == 'E' {
+		p.pos++
+		if c := p.peek(); c == '-' || c == '+' {
+			p.pos++
+		}
+		exponent := p.pos
+		for isDigit(p.peek()) {
+			p.pos++
+		}
+		if p.pos == exponent {
+			return 0, p.errorf("invalid number")
+		}
+	}
+	n, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+	if err != nil {
+		return 0, p.errorf("invalid number")
+	}
+	return n, nil
+}
+
+func (p *jpParser) parseCall(name string, start int) (*jpCall, error) {
+	function, ok := p.functions[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown function " + name)
+	}
+	call := &jpCall{function: function}
+	// skip the opening parenthesis
+	p.pos++
+	p.skipBlanks()
+	for p.peek() != ')' {
+		if len(call.args) > 0 {
+			if p.peek() != ',' {
+				return nil, p.errorf("expected , or )")
+			}
+			p.pos++
+			p.skipBlanks()
+		}
+		if len(call.args) == len(function.params) {
+			return nil, p.errorf("too many arguments for " + name)
+		}
+		arg, err := p.parseArgument(function.params[len(call.args)])
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		p.skipBlanks()
+	}
+	p.pos++
+	if len(call.args) != len(function.params) {
+		return nil, p.errorf("too few arguments for " + name)
+	}
+	return call, nil
+}
+
+func (p *jpParser) parseArgument(param int) (func(current, root *Jsonic) interface{}, error) {
+	start := p.pos
+	if c := p.peek(); c != '!' && c != '(' {
+		// check whether the argument is just an operand
+		operand, err := p.parseOperand()
+		if err == nil {
+			end := p.pos
+			p.skipBlanks()
+			if c := p.peek(); c == ',' || c == ')' {
+				p.pos = end
+				return p.argumentOf(operand, param, start)
+			}
+		}
+		p.pos = start
+	}
+	// otherwise it should be a logical expression
+	expr, err := p.parseLogical()
+	if err != nil {
+		return nil, err
+	}
+	if param != jpLogicalType {
+		p.pos = start
+		return nil, p.errorf("logical expression is not expected here")
+	}
+	return func(current, root *Jsonic) interface{} {
+		return expr.test(current, root)
+	}, nil
+}
+
+func (p *jpParser) argumentOf(operand jpOperand, param, start int) (func(current, root *Jsonic) interface{}, error) {
+	switch param {
+	case jpValueType:
+		c, err := p.comparableOf(operand, start)
+		if err != nil {
+			return nil, err
+		}
+		return c.value, nil
+	case jpNodesType:
+		if operand.query != nil {
+			query := operand.query
+			return func(current, root *Jsonic) interface{} {
+				return query.nodes(current, root)
+			}, nil
+		}
+		if operand.call != nil && operand.call.function.result == jpNodesType {
+			return operand.call.evaluate, nil
+		}
+	case jpLogicalType:
+		if !operand.literal {
+			test, err := p.testOf(operand, start)
+			if err != nil {
+				return nil, err
+			}
+			return func(current, root *Jsonic) interface{} {
+				return test.test(current, root)
+			}, nil
+		}
+	}
+	p.pos = start
+	return nil, p.errorf("argument is not of the expected type")
+}
+
+// comparableOf validates that the operand can be compared.
+func (p *jpParser) comparableOf(operand jpOperand, start int) (jpComparable, error) {
+	switch {
+	case operand.literal:
+		return jpLiteral{data: operand.data}, nil
+	case operand.query != nil:
+		if operand.query.singular {
+			return operand.query, nil
+		}
+	case operand.call != nil:
+		if operand.call.function.result == jpValueType {
+			return operand.call, nil
+		}
+	}
+	p.pos = start
+	return nil, p.errorf("only the singular queries and the functions returning a value can be compared")
+}
+
+// testOf validates that the operand can be tested.
+func (p *jpParser) testOf(operand jpOperand, start int) (jpLogical, error) {
+	if operand.query != nil {
+		return jpExists{query: operand.query}, nil
+	}
+	if operand.call.function.result == jpValueType {
+		p.pos = start
+		return nil, p.errorf("function returning a value cannot be tested")
+	}
+	return jpTest{call: operand.call}, nil
+}
+
+// isSingular reports whether the segments can select at most a single json tree.
+func isSingular(segments []jpSegment) bool {
+	for _, segment := range segments {
+		if segment.descendant || len(segment.selectors) != 1 {
+			return false
+		}
+		if kind := segment.selectors[0].kind; kind != jpName && kind != jpIndex {
+			return false
+		}
+	}
+	return true
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
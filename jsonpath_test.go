@@ -0,0 +1,153 @@
+package jsonic_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+type complianceTest struct {
+	Name            string          `json:"name"`
+	Selector        string          `json:"selector"`
+	Document        json.RawMessage `json:"document"`
+	Result          []interface{}   `json:"result"`
+	Results         [][]interface{} `json:"results"`
+	InvalidSelector bool            `json:"invalid_selector"`
+}
+
+func TestJSONPathExamples(t *testing.T) {
+	runComplianceTests(t, readFromFile("test_data/jsonpath/rfc9535.json", t))
+}
+
+// TestJSONPathCompliance runs the tests of the JSONPath compliance test suite, vendored as
+// test_data/jsonpath/cts.json from https://github.com/jsonpath-standard/jsonpath-compliance-test-suite
+// along with its license, and the commit it is taken from in test_data/jsonpath/cts.commit.
+func TestJSONPathCompliance(t *testing.T) {
+	data, err := os.ReadFile("test_data/jsonpath/cts.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skip("the compliance test suite is not vendored in test_data/jsonpath/cts.json")
+	}
+	assert.NoError(t, err)
+	commit, err := os.ReadFile("test_data/jsonpath/cts.commit")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, strings.TrimSpace(string(commit)))
+	_, err = os.Stat("test_data/jsonpath/LICENSE")
+	assert.NoError(t, err)
+	runComplianceTests(t, data)
+}
+
+func runComplianceTests(t *testing.T, data []byte) {
+	var suite struct {
+		Tests []complianceTest `json:"tests"`
+	}
+	err := json.Unmarshal(data, &suite)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, suite.Tests)
+
+	for _, test := range suite.Tests {
+		test := test
+		t.Run(test.Name, func(t *testing.T) {
+			q, err := jsonic.JSONPath(test.Selector)
+			if test.InvalidSelector {
+				assert.Error(t, err)
+				assert.Nil(t, q)
+				return
+			}
+			assert.NoError(t, err)
+			if !assert.NotNil(t, q) {
+				return
+			}
+			j, err := jsonic.New(test.Document)
+			assert.NoError(t, err)
+			data := make([]interface{}, 0)
+			for _, r := range q.Query(j) {
+				v, err := r.Get(".")
+				assert.NoError(t, err)
+				data = append(data, v)
+			}
+			if test.Results != nil {
+				assert.Contains(t, test.Results, data)
+				return
+			}
+			assert.Equal(t, test.Result, data)
+		})
+	}
+}
+
+func TestQueryJSONPath(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test7.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	results, err := j.QueryJSONPath("$.store.books[?@.price > 10].title")
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	for _, r := range results {
+		s, err := r.GetString(".")
+		assert.NoError(t, err)
+		assert.Contains(t, []string{"boruto", "sasuke"}, s)
+	}
+
+	// the results are connected to the json tree
+	c, err := j.Child("store.books.[0]")
+	assert.NoError(t, err)
+	results, err = j.QueryJSONPath("$.store.books[0]")
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, c, results[0])
+
+	// the query can be reused, with the child as the root
+	q, err := jsonic.JSONPath("$.title")
+	assert.NoError(t, err)
+	assert.Equal(t, "$.title", q.String())
+	results = q.Query(c)
+	assert.Len(t, results, 1)
+	s, err := results[0].GetString(".")
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", s)
+}
+
+func TestJSONPathPatternsFromData(t *testing.T) {
+	// more patterns than the ones cached
+	var b strings.Builder
+	b.WriteString("[")
+	for i := 0; i < 1000; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(fmt.Sprintf(`{"pattern": "v%d.", "value": "v%dx"}`, i, i))
+	}
+	b.WriteString(`, {"pattern": "(", "value": "("}]`)
+	j, err := jsonic.New([]byte(b.String()))
+	assert.NoError(t, err)
+	for _, expr := range []string{"$[?match(@.value, @.pattern)]", "$[?search(@.value, @.pattern)]"} {
+		results, err := j.QueryJSONPath(expr)
+		assert.NoError(t, err)
+		assert.Len(t, results, 1000, expr)
+	}
+	results, err := j.QueryJSONPath("$[?match(@.value, $[0].pattern)]")
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
+func TestJSONPathError(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test7.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	results, err := j.QueryJSONPath("$.store[01]")
+	assert.Error(t, err)
+	assert.Nil(t, results)
+	e, ok := err.(*jsonic.JSONPathError)
+	assert.True(t, ok)
+	assert.Equal(t, "$.store[01]", e.Expr)
+	assert.Equal(t, 10, e.Offset)
+	assert.Equal(t, jsonic.ErrInvalidPath, e.Unwrap())
+}
@@ -0,0 +1,63 @@
+package jsonic
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Kind is the kind of the json data.
+//
+// The kinds can be combined, like KindArray | KindObject, to denote any of them.
+type Kind int
+
+// kinds of the json data
+const (
+	KindNull Kind = 1 << iota
+	KindBool
+	KindNumber
+	KindString
+	KindArray
+	KindObject
+)
+
+var kindNames = []string{"null", "bool", "number", "string", "array", "object"}
+
+// Kind returns the kind of the data of this json tree.
+func (j *Jsonic) Kind() Kind {
+	return kindOf(j.data)
+}
+
+// String returns the name of the kind, like array, or the names
+// of all the kinds combined, like array or object.
+func (k Kind) String() string {
+	var names []string
+	for i, name := range kindNames {
+		if k&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "unknown"
+	}
+	return strings.Join(names, " or ")
+}
+
+func kindOf(data interface{}) Kind {
+	switch data.(type) {
+	case nil:
+		return KindNull
+	case bool:
+		return KindBool
+	case float64, json.Number:
+		return KindNumber
+	case string:
+		return KindString
+	case []interface{}:
+		return KindArray
+	case map[string]interface{}:
+		return KindObject
+	case rawValue:
+		return rawKind(data.(rawValue))
+	}
+	return 0
+}
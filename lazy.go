@@ -0,0 +1,182 @@
+package jsonic
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// rawValue is the json data not unmarshalled yet, which is used in the lazy mode.
+type rawValue []byte
+
+// Lazy is used to unmarshal only the parts of the json data which are accessed.
+//
+// The data is only validated when the instance is created, and each of the objects
+// and the arrays is split into its keys or elements on being accessed the first time,
+// while the values not accessed are left as they are. So retrieving a few values
+// from a large json data does not cost unmarshalling all of it. The data provided
+// is kept as it is, so it should not be modified afterwards.
+//
+// As the json tree is updated while it is being read, it is not safe for concurrent
+// use in this mode.
+func Lazy() Option {
+	return func(o *options) {
+		o.lazy = true
+	}
+}
+
+// shallow returns the data of this json tree, where the values within
+// the objects and the arrays might not be unmarshalled yet.
+func (j *Jsonic) shallow() interface{} {
+	raw, ok := j.data.(rawValue)
+	if !ok {
+		return j.data
+	}
+	if current, ok := j.inParent(); ok {
+		// already unmarshalled using the parent
+		j.data = current
+		return current
+	}
+	j.setData(j.opts.split(raw))
+	return j.data
+}
+
+// value returns the data of this json tree, unmarshalling whatever is left of it.
+func (j *Jsonic) value() interface{} {
+	if !j.opts.lazy {
+		return j.data
+	}
+	data := j.shallow()
+	switch data.(type) {
+	case map[string]interface{}, []interface{}:
+		j.opts.unmarshalRaw(data)
+	}
+	return data
+}
+
+// inParent returns the data of this json tree in the parent, in case it is not a raw value anymore.
+func (j *Jsonic) inParent() (interface{}, bool) {
+	if j.parent == nil {
+		return nil, false
+	}
+	var current interface{}
+	switch container := j.parent.data.(type) {
+	case map[string]interface{}:
+		v, ok := container[j.key]
+		if !ok {
+			return nil, false
+		}
+		current = v
+	case []interface{}:
+		index, err := strconv.Atoi(j.key)
+		if err != nil || index >= len(container) {
+			return nil, false
+		}
+		current = container[index]
+	default:
+		return nil, false
+	}
+	if _, ok := current.(rawValue); ok {
+		return nil, false
+	}
+	return current, true
+}
+
+// split unmarshals only the top level of the raw value, so that an object or an array
+// contains the raw values of its keys or elements.
+func (o *options) split(raw rawValue) interface{} {
+	i := skipSpace(raw, 0)
+	switch raw[i] {
+	case '{':
+		object := make(map[string]interface{})
+		i = skipSpace(raw, i+1)
+		if raw[i] == '}' {
+			return object
+		}
+		for {
+			end, _ := skipString(raw, i)
+			key := unquote(raw[i:end])
+			i = skipSpace(raw, skipSpace(raw, end)+1)
+			end, _ = skipValue(raw, i, 0, o.useNumber)
+			object[key] = raw[i:end]
+			i = skipSpace(raw, end)
+			if raw[i] == '}' {
+				return object
+			}
+			i = skipSpace(raw, i+1)
+		}
+	case '[':
+		var array []interface{}
+		i = skipSpace(raw, i+1)
+		if raw[i] == ']' {
+			return make([]interface{}, 0)
+		}
+		for {
+			end, _ := skipValue(raw, i, 0, o.useNumber)
+			array = append(array, raw[i:end])
+			i = skipSpace(raw, end)
+			if raw[i] == ']' {
+				return array
+			}
+			i = skipSpace(raw, i+1)
+		}
+	case '"':
+		end, _ := skipString(raw, i)
+		return unquote(raw[i:end])
+	case 't':
+		return true
+	case 'f':
+		return false
+	case 'n':
+		return nil
+	}
+	end, _ := skipNumber(raw, i, o.useNumber)
+	if o.useNumber {
+		return json.Number(raw[i:end])
+	}
+	f, _ := strconv.ParseFloat(string(raw[i:end]), 64)
+	return f
+}
+
+// unmarshalRaw replaces the raw values within the objects and the arrays with their data.
+func (o *options) unmarshalRaw(data interface{}) {
+	switch container := data.(type) {
+	case map[string]interface{}:
+		for k, v := range container {
+			container[k] = o.unmarshalled(v)
+		}
+	case []interface{}:
+		for i, v := range container {
+			container[i] = o.unmarshalled(v)
+		}
+	}
+}
+
+func (o *options) unmarshalled(data interface{}) interface{} {
+	if raw, ok := data.(rawValue); ok {
+		data, _ = o.unmarshal(raw)
+		return data
+	}
+	o.unmarshalRaw(data)
+	return data
+}
+
+// rawKind returns the kind of the raw value without unmarshalling it.
+func rawKind(raw rawValue) Kind {
+	i := skipSpace(raw, 0)
+	if i == len(raw) {
+		return 0
+	}
+	switch raw[i] {
+	case '{':
+		return KindObject
+	case '[':
+		return KindArray
+	case '"':
+		return KindString
+	case 't', 'f':
+		return KindBool
+	case 'n':
+		return KindNull
+	}
+	return KindNumber
+}
@@ -0,0 +1,161 @@
+package jsonic_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazySameAsEager(t *testing.T) {
+	cases := map[string][]string{
+		"test_data/test1.json": {".", "a", "a.x", "a.arr[0].c.d.e", "a.x.y", "a.x.y.z", "c", "a.arr[-1].a", "x"},
+		"test_data/test2.json": {"a", "b", "c", "d", "e", "e[1]", "f", "h[0]", "i", "l.naruto", "e[5]"},
+		"test_data/test9.json": {"items[*].name", "items[?(@.price > 10)].id", "..rank", "items[1:3].tags",
+			"items[?(@.meta.rank == 'genin')].name", "items[0].a\\.b", "/items/1/isbn"},
+	}
+	for file, paths := range cases {
+		data := readFromFile(file, t)
+		eager, err := jsonic.New(data)
+		assert.NoError(t, err)
+		lazy, err := jsonic.NewWithOptions(data, jsonic.Lazy())
+		assert.NoError(t, err)
+		for _, path := range paths {
+			expected, expectedErr := eager.Get(path)
+			actual, actualErr := lazy.Get(path)
+			assert.Equal(t, expected, actual, file+" "+path)
+			assert.Equal(t, expectedErr, actualErr, file+" "+path)
+
+			expectedAll, expectedErr := eager.Query(path)
+			actualAll, actualErr := lazy.Query(path)
+			assert.Equal(t, expectedErr, actualErr, file+" "+path)
+			assert.Equal(t, len(expectedAll), len(actualAll), file+" "+path)
+			for i := range expectedAll {
+				e, _ := expectedAll[i].Get(".")
+				a, _ := actualAll[i].Get(".")
+				assert.Equal(t, e, a, file+" "+path)
+			}
+		}
+		expected, err := eager.Bytes()
+		assert.NoError(t, err)
+		actual, err := lazy.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual), file)
+	}
+}
+
+func TestLazyTyped(t *testing.T) {
+	j, err := jsonic.NewWithOptions(readFromFile("test_data/test2.json", t), jsonic.Lazy(), jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i, err := j.GetInt("a")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+	f, err := j.GetFloat64("b")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.2, f)
+	s, err := j.GetString("d")
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", s)
+	sa, err := j.GetStringArray("h")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"naruto", "boruto"}, sa)
+	m, err := j.GetFloat64Map("j")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"naruto": 1.1}, m)
+	assert.Equal(t, jsonic.KindArray, mustChild(t, j, "e").Kind())
+
+	_, err = j.GetInt("d")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	var pathErr *jsonic.PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, jsonic.KindString, pathErr.Actual)
+}
+
+func TestLazyMutation(t *testing.T) {
+	j, err := jsonic.NewWithOptions([]byte(`{"a": {"b": [1, 2], "c": "d"}, "e": "é"}`), jsonic.Lazy())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	child := mustChild(t, j, "a")
+	assert.NoError(t, child.Append("b", 3))
+	assert.NoError(t, j.Set("a.x", true))
+	assert.NoError(t, j.Delete("a.c"))
+
+	b, err := j.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"b":[1,2,3],"x":true},"e":"é"}`, string(b))
+	b, err = child.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b":[1,2,3],"x":true}`, string(b))
+}
+
+func TestLazyInvalid(t *testing.T) {
+	for _, data := range []string{`{"a": }`, `[1, 2`, `{"a": 1e400}`, `"\x"`, `{"a": 1} x`, ``} {
+		_, expected := jsonic.New([]byte(data))
+		_, err := jsonic.NewWithOptions([]byte(data), jsonic.Lazy())
+		assert.Error(t, err, data)
+		assert.Equal(t, expected, err, data)
+	}
+}
+
+func TestLazyDepth(t *testing.T) {
+	// as deep as allowed by encoding/json
+	data := strings.Repeat("[", 10000) + strings.Repeat("]", 10000)
+	j, err := jsonic.NewWithOptions([]byte(data), jsonic.Lazy())
+	assert.NoError(t, err)
+	b, err := j.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, data, string(b))
+
+	for _, data := range []string{"[" + data + "]", `{"a": ` + strings.Repeat(`{"a": `, 10000) + "1" + strings.Repeat("}", 10001),
+		strings.Repeat("[", 20000000)} {
+		_, expected := jsonic.New([]byte(data))
+		_, err := jsonic.NewWithOptions([]byte(data), jsonic.Lazy())
+		assert.Error(t, err)
+		assert.Equal(t, expected, err)
+	}
+}
+
+func mustChild(t *testing.T, j *jsonic.Jsonic, path string) *jsonic.Jsonic {
+	child, err := j.Child(path)
+	assert.NoError(t, err)
+	return child
+}
+
+func largeJSON() []byte {
+	var b strings.Builder
+	b.WriteString(`{"items": [`)
+	for i := 0; i < 10000; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id": %d, "name": "item %d", "tags": ["a", "b", "c"], "price": %d.5}`, i, i, i)
+	}
+	b.WriteString(`], "meta": {"id": "naruto"}}`)
+	return []byte(b.String())
+}
+
+func BenchmarkGetStringLarge(b *testing.B) {
+	data := largeJSON()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j, _ := jsonic.New(data)
+		_, _ = j.GetString("meta.id")
+	}
+}
+
+func BenchmarkGetStringLargeLazy(b *testing.B) {
+	data := largeJSON()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j, _ := jsonic.NewWithOptions(data, jsonic.Lazy())
+		_, _ = j.GetString("meta.id")
+	}
+}
@@ -0,0 +1,180 @@
+package jsonic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// Lines iterates the json values read from the json lines, also known as the newline
+// delimited json, where each of the lines contains a json value.
+//
+// A line can also contain multiple json values one after the other, with or without
+// the whitespaces between them, and the empty lines are ignored.
+//
+// It is used like bufio.Scanner.
+//
+//	lines := jsonic.NewLines(r)
+//	for lines.Next() {
+//		j := lines.Value()
+//	}
+//	err := lines.Err()
+type Lines struct {
+	reader  *bufio.Reader
+	opts    *options
+	rest    []byte
+	line    int
+	eof     bool
+	current *Jsonic
+	err     error
+}
+
+// LinesWriter writes the json values as the json lines.
+type LinesWriter struct {
+	w io.Writer
+	e *encoder
+}
+
+// SkipMalformedLines is used to make Lines skip the lines which are not valid json,
+// instead of stopping at the first of them. The function provided, unless it is nil,
+// is called with the *LineError of each of such lines.
+//
+// Note that once a json value in a line is not valid, the rest of the line is skipped.
+func SkipMalformedLines(f func(err error)) Option {
+	return func(o *options) {
+		o.skipMalformed = true
+		o.malformed = f
+	}
+}
+
+// NewLines is used to iterate the json values read from the json lines,
+// configured with the options provided.
+func NewLines(r io.Reader, opts ...Option) *Lines {
+	return &Lines{reader: bufio.NewReader(r), opts: newOptions(opts)}
+}
+
+// Next moves to the next json value, which is then available through Value.
+//
+// It returns false when there are no more values, either because the end
+// of the data is reached, or there is an error, which is returned by Err.
+// In case a line is not a valid json, including the objects and the arrays nested
+// deeper than encoding/json allows, the error is a *LineError.
+func (l *Lines) Next() bool {
+	l.current = nil
+	for l.err == nil {
+		i := skipSpace(l.rest, 0)
+		if i == len(l.rest) {
+			if l.eof {
+				return false
+			}
+			l.readLine()
+			continue
+		}
+		end, ok := skipValue(l.rest, i, 0, l.opts.useNumber)
+		if !ok {
+			err := &LineError{Line: l.line, Err: l.opts.syntaxError(l.rest[i:])}
+			// the rest of the line is skipped
+			l.rest = nil
+			if !l.opts.skipMalformed {
+				l.err = err
+				return false
+			}
+			if l.opts.malformed != nil {
+				l.opts.malformed(err)
+			}
+			continue
+		}
+		value := l.rest[i:end]
+		l.rest = l.rest[end:]
+		if l.opts.lazy {
+			// each line is read into a new slice, so the value is not modified afterwards
+			l.current = new(rawValue(value), l.opts)
+			return true
+		}
+		data, err := l.opts.unmarshal(value)
+		if err != nil {
+			l.err = &LineError{Line: l.line, Err: err}
+			return false
+		}
+		l.current = new(data, l.opts)
+		return true
+	}
+	return false
+}
+
+// Value returns the json value the iterator is at.
+func (l *Lines) Value() *Jsonic {
+	return l.current
+}
+
+// Line returns the number of the line the json value is read from, beginning with 1.
+func (l *Lines) Line() int {
+	return l.line
+}
+
+// Err returns the error which stopped the iteration, or nil in case the end of the data is reached.
+func (l *Lines) Err() error {
+	return l.err
+}
+
+func (l *Lines) readLine() {
+	line, err := l.reader.ReadBytes('\n')
+	if err == io.EOF {
+		l.eof = true
+	} else if err != nil {
+		l.err = err
+		return
+	}
+	l.line++
+	l.rest = line
+}
+
+// syntaxError returns the error reported by the standard library for the json value
+// beginning in the data, which is not valid.
+func (o *options) syntaxError(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if o.useNumber {
+		decoder.UseNumber()
+	}
+	var discarded interface{}
+	err := decoder.Decode(&discarded)
+	if err == nil || err == io.EOF {
+		return ErrInvalidJSON
+	}
+	return err
+}
+
+// NewLinesWriter is used to write the json values as the json lines to the writer,
+// encoded as per the options provided.
+//
+// As each of the values has to be in a single line, the Indent option is ignored.
+func NewLinesWriter(w io.Writer, opts ...EncodeOption) *LinesWriter {
+	e := newEncoder(opts)
+	e.pretty = false
+	return &LinesWriter{w: w, e: e}
+}
+
+// Write writes the json value followed by a new line.
+func (w *LinesWriter) Write(j *Jsonic) error {
+	w.e.buf = w.e.buf[:0]
+	err := w.e.encode(j.value(), 0)
+	if err != nil {
+		return err
+	}
+	w.e.buf = append(w.e.buf, '\n')
+	_, err = w.w.Write(w.e.buf)
+	return err
+}
+
+// WriteLines writes the json values as the json lines to the writer, encoded as per the options provided.
+func WriteLines(w io.Writer, values []*Jsonic, opts ...EncodeOption) error {
+	lw := NewLinesWriter(w, opts...)
+	for _, j := range values {
+		err := lw.Write(j)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package jsonic_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	line int
+	data string
+}
+
+// readLines returns the values read from the json lines along with their line numbers.
+func readLines(t *testing.T, lines *jsonic.Lines) []record {
+	var records []record
+	for lines.Next() {
+		b, err := lines.Value().Bytes()
+		assert.NoError(t, err)
+		records = append(records, record{line: lines.Line(), data: string(b)})
+	}
+	return records
+}
+
+func TestLinesStopOnMalformed(t *testing.T) {
+	lines := jsonic.NewLines(bytes.NewReader(readFromFile("test_data/test15.jsonl", t)))
+	assert.Equal(t, []record{
+		{1, `{"id":1,"name":"naruto"}`},
+		{2, `{"id":2,"name":"sasuke"}`},
+		{4, `{"id":3,"name":"kakashi"}`},
+		{4, `{"id":4,"name":"sakura"}`},
+		{4, `{"id":5}`},
+	}, readLines(t, lines))
+	assert.Nil(t, lines.Value())
+	var lineErr *jsonic.LineError
+	assert.True(t, errors.As(lines.Err(), &lineErr))
+	assert.Equal(t, 5, lineErr.Line)
+	assert.Error(t, lineErr.Err)
+	assert.True(t, strings.HasPrefix(lines.Err().Error(), "jsonic: line 5: "))
+	// it stays stopped
+	assert.False(t, lines.Next())
+}
+
+func TestLinesSkipMalformed(t *testing.T) {
+	var skipped []int
+	lines := jsonic.NewLines(bytes.NewReader(readFromFile("test_data/test15.jsonl", t)),
+		jsonic.SkipMalformedLines(func(err error) {
+			var lineErr *jsonic.LineError
+			assert.True(t, errors.As(err, &lineErr))
+			skipped = append(skipped, lineErr.Line)
+		}))
+	assert.Equal(t, []record{
+		{1, `{"id":1,"name":"naruto"}`},
+		{2, `{"id":2,"name":"sasuke"}`},
+		{4, `{"id":3,"name":"kakashi"}`},
+		{4, `{"id":4,"name":"sakura"}`},
+		{4, `{"id":5}`},
+		{6, `[1,2,3]`},
+		{7, `"text"`},
+		{8, `{"id":7}`},
+		// the number is parsed as float64
+		{9, `9007199254740992`},
+	}, readLines(t, lines))
+	assert.NoError(t, lines.Err())
+	assert.Equal(t, []int{5, 8}, skipped)
+
+	lines = jsonic.NewLines(strings.NewReader("x\n1"), jsonic.SkipMalformedLines(nil))
+	assert.Equal(t, []record{{2, `1`}}, readLines(t, lines))
+	assert.NoError(t, lines.Err())
+}
+
+func TestLinesDepth(t *testing.T) {
+	data := strings.Repeat("[", 20000000) + "\n" + strings.Repeat("[", 10000) + strings.Repeat("]", 10000) + "\n{\"id\": 1}\n"
+	for _, opts := range [][]jsonic.Option{nil, {jsonic.Lazy()}} {
+		lines := jsonic.NewLines(strings.NewReader(data), opts...)
+		assert.False(t, lines.Next())
+		var lineErr *jsonic.LineError
+		assert.True(t, errors.As(lines.Err(), &lineErr))
+		assert.Equal(t, 1, lineErr.Line)
+		assert.Contains(t, lineErr.Error(), "exceeded max depth")
+
+		var skipped []int
+		lines = jsonic.NewLines(strings.NewReader(data), append(opts, jsonic.SkipMalformedLines(func(err error) {
+			var lineErr *jsonic.LineError
+			assert.True(t, errors.As(err, &lineErr))
+			skipped = append(skipped, lineErr.Line)
+		}))...)
+		var read []int
+		for lines.Next() {
+			read = append(read, lines.Line())
+		}
+		assert.NoError(t, lines.Err())
+		assert.Equal(t, []int{1}, skipped)
+		assert.Equal(t, []int{2, 3}, read)
+	}
+}
+
+func TestLinesOptions(t *testing.T) {
+	for _, opts := range [][]jsonic.Option{{jsonic.UseNumber()}, {jsonic.UseNumber(), jsonic.Lazy()}} {
+		lines := jsonic.NewLines(strings.NewReader("{\"id\": 9007199254740993}\r\n{\"id\": 1}\r\n"), opts...)
+		var ids []int64
+		for lines.Next() {
+			id, err := lines.Value().GetInt64("id")
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+		assert.NoError(t, lines.Err())
+		assert.Equal(t, []int64{9007199254740993, 1}, ids)
+	}
+
+	// the numbers not fitting in a float64 are not valid without UseNumber
+	lines := jsonic.NewLines(strings.NewReader("1e400"))
+	assert.False(t, lines.Next())
+	assert.Error(t, lines.Err())
+	lines = jsonic.NewLines(strings.NewReader("1e400"), jsonic.UseNumber())
+	assert.True(t, lines.Next())
+	assert.NoError(t, lines.Err())
+}
+
+func TestLinesEmpty(t *testing.T) {
+	for _, data := range []string{"", "\n", " \n\t\n"} {
+		lines := jsonic.NewLines(strings.NewReader(data))
+		assert.False(t, lines.Next(), data)
+		assert.NoError(t, lines.Err(), data)
+	}
+}
+
+func TestLinesWriter(t *testing.T) {
+	var values []*jsonic.Jsonic
+	lines := jsonic.NewLines(strings.NewReader(`{"b": "<x>", "a": [1, 2]} 1 "s" null`))
+	for lines.Next() {
+		values = append(values, lines.Value())
+	}
+	assert.NoError(t, lines.Err())
+	child, err := values[0].Child("a")
+	assert.NoError(t, err)
+	values = append(values, child)
+
+	var b bytes.Buffer
+	assert.NoError(t, jsonic.WriteLines(&b, values))
+	assert.Equal(t, "{\"a\":[1,2],\"b\":\"\\u003cx\\u003e\"}\n1\n\"s\"\nnull\n[1,2]\n", b.String())
+
+	b.Reset()
+	w := jsonic.NewLinesWriter(&b, jsonic.Indent("", "  "), jsonic.EscapeHTML(false))
+	for _, j := range values {
+		assert.NoError(t, w.Write(j))
+	}
+	assert.Equal(t, "{\"a\":[1,2],\"b\":\"<x>\"}\n1\n\"s\"\nnull\n[1,2]\n", b.String())
+
+	// the json lines written can be read back
+	lines = jsonic.NewLines(&b)
+	count := 0
+	for ; lines.Next(); count++ {
+		assert.Equal(t, count+1, lines.Line())
+	}
+	assert.NoError(t, lines.Err())
+	assert.Equal(t, len(values), count)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("failed")
+}
+
+func TestLinesWriterError(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": 1}`))
+	assert.NoError(t, err)
+	assert.EqualError(t, jsonic.WriteLines(failingWriter{}, []*jsonic.Jsonic{j, j}), "failed")
+}
@@ -0,0 +1,335 @@
+package jsonic
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConflictPolicy is the way the conflicting data of the json trees being merged is resolved.
+type ConflictPolicy int
+
+// policies for the conflicts
+const (
+	// ConflictLastWins is for keeping the data of the json tree merged last.
+	ConflictLastWins ConflictPolicy = iota
+	// ConflictFirstWins is for keeping the data of the json tree merged first.
+	ConflictFirstWins
+	// ConflictError is for failing the merge with a *MergeError.
+	ConflictError
+)
+
+// the ways the arrays are merged
+const (
+	arraysReplace = iota
+	arraysAppend
+	arraysByIndex
+	arraysByKey
+)
+
+// MergeOption is used to configure the way the json trees are merged.
+type MergeOption func(*merger)
+
+type arrayStrategy struct {
+	kind int
+	// the key identifying the objects, used for arraysByKey
+	key string
+}
+
+// pathStrategy is the array strategy for the arrays at the path.
+type pathStrategy struct {
+	path     string
+	strategy arrayStrategy
+}
+
+type merger struct {
+	fallback arrayStrategy
+	paths    []pathStrategy
+	conflict ConflictPolicy
+	// the index of the json tree being merged
+	source int
+	// the array strategies for the json pointers of the json tree being merged
+	strategies map[string]arrayStrategy
+}
+
+// sourced is the data in the merged json tree, other than the objects and the arrays
+// having data, along with the index of the json tree it came from.
+type sourced struct {
+	data   interface{}
+	source int
+}
+
+// ReplaceArrays is used to replace the arrays with the ones merged later, treating
+// them like any other conflicting data. It is the default for all the arrays.
+//
+// The paths are the same as the ones accepted by Query, and they are resolved on each
+// of the json trees being merged. Without any paths, it applies to all the arrays
+// not matched by the paths of any of the options.
+func ReplaceArrays(paths ...string) MergeOption {
+	return arrays(arrayStrategy{kind: arraysReplace}, paths)
+}
+
+// AppendArrays is used to append the elements of the arrays merged later.
+//
+// The paths are resolved in the same way as for ReplaceArrays.
+func AppendArrays(paths ...string) MergeOption {
+	return arrays(arrayStrategy{kind: arraysAppend}, paths)
+}
+
+// MergeArraysByIndex is used to merge the elements of the arrays at the same index,
+// appending the extra elements of the arrays merged later.
+//
+// The paths are resolved in the same way as for ReplaceArrays.
+func MergeArraysByIndex(paths ...string) MergeOption {
+	return arrays(arrayStrategy{kind: arraysByIndex}, paths)
+}
+
+// UnionArraysByKey is used to merge the objects of the arrays having the same value
+// for the key, appending the ones not present already. The elements which are not
+// objects having the key are appended unless there is an element equal to them.
+//
+// The paths are resolved in the same way as for ReplaceArrays.
+func UnionArraysByKey(key string, paths ...string) MergeOption {
+	return arrays(arrayStrategy{kind: arraysByKey, key: key}, paths)
+}
+
+// OnConflict is used to set the policy for the conflicting data, which is ConflictLastWins by default.
+func OnConflict(policy ConflictPolicy) MergeOption {
+	return func(m *merger) {
+		m.conflict = policy
+	}
+}
+
+func arrays(strategy arrayStrategy, paths []string) MergeOption {
+	return func(m *merger) {
+		if len(paths) == 0 {
+			m.fallback = strategy
+			return
+		}
+		for _, path := range paths {
+			m.paths = append(m.paths, pathStrategy{path: path, strategy: strategy})
+		}
+	}
+}
+
+// Merge returns a new json tree with the json trees merged one after the other,
+// along with the json tree each of the values in it came from.
+//
+// The objects are merged key by key, and the arrays as per the strategies configured,
+// replacing them by default. Any other data, including the data of different kinds,
+// conflicts unless it is equal, in which case it is resolved as per the conflict policy.
+// The paths provided in the options which are not valid return ErrInvalidPath.
+//
+// The sources returned map the json pointer of each of the values in the merged json
+// tree, other than the objects and the arrays having data, to the index of the json tree
+// it came from. The json trees are not modified, and the merged one uses the options of
+// the first one. Without any json trees, it returns a json tree with null.
+func Merge(docs []*Jsonic, opts ...MergeOption) (*Jsonic, map[string]int, error) {
+	m := &merger{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	sources := make(map[string]int)
+	if len(docs) == 0 {
+		return new(nil, newOptions(nil)), sources, nil
+	}
+	merged := wrapSourced(docs[0].value(), 0)
+	for i := 1; i < len(docs); i++ {
+		strategies, err := m.strategiesOf(docs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		m.source = i
+		m.strategies = strategies
+		merged, err = m.merge(merged, docs[i].value(), empty, empty)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return new(unwrapSourced(merged, empty, sources), docs[0].opts), sources, nil
+}
+
+// strategiesOf returns the array strategies for the json pointers, relative to the json tree,
+// of the data at the paths.
+func (m *merger) strategiesOf(j *Jsonic) (map[string]arrayStrategy, error) {
+	strategies := make(map[string]arrayStrategy)
+	prefix := j.Pointer()
+	for _, p := range m.paths {
+		results, err := j.Query(p.path)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			strategies[strings.TrimPrefix(result.Pointer(), prefix)] = p.strategy
+		}
+	}
+	return strategies, nil
+}
+
+// merge merges the data at the json pointer of the json tree being merged into the merged data
+// at the json pointer of the merged json tree.
+func (m *merger) merge(merged, data interface{}, pointer, at string) (interface{}, error) {
+	current := merged
+	if s, ok := merged.(sourced); ok && kindOf(s.data) == kindOf(data) {
+		// the empty object or array
+		current = s.data
+	}
+	switch x := current.(type) {
+	case map[string]interface{}:
+		if y, ok := data.(map[string]interface{}); ok {
+			return m.mergeObjects(merged, x, y, pointer, at)
+		}
+	case []interface{}:
+		if y, ok := data.([]interface{}); ok {
+			return m.mergeArrays(merged, x, y, pointer, at)
+		}
+	}
+	return m.resolve(merged, data, pointer)
+}
+
+func (m *merger) mergeObjects(merged interface{}, x, y map[string]interface{}, pointer, at string) (interface{}, error) {
+	if len(y) == 0 {
+		if len(x) > 0 {
+			return merged, nil
+		}
+		return m.resolve(merged, y, pointer)
+	}
+	keys := make([]string, 0, len(y))
+	for k := range y {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		e, ok := x[k]
+		if !ok {
+			x[k] = wrapSourced(y[k], m.source)
+			continue
+		}
+		token := slash + escapeToken(k)
+		var err error
+		x[k], err = m.merge(e, y[k], pointer+token, at+token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+func (m *merger) mergeArrays(merged interface{}, x, y []interface{}, pointer, at string) (interface{}, error) {
+	strategy, ok := m.strategies[at]
+	if !ok {
+		strategy = m.fallback
+	}
+	if strategy.kind == arraysReplace || (len(x) == 0 && len(y) == 0) {
+		return m.resolve(merged, y, pointer)
+	}
+	for i, e := range y {
+		index := -1
+		switch {
+		case strategy.kind == arraysByIndex && i < len(x):
+			index = i
+		case strategy.kind == arraysByKey:
+			index = indexByKey(x, e, strategy.key)
+		}
+		if index < 0 {
+			x = append(x, wrapSourced(e, m.source))
+			continue
+		}
+		var err error
+		x[index], err = m.merge(x[index], e, pointer+slash+strconv.Itoa(index), at+slash+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+// resolve resolves the merged data and the data being merged as per the conflict policy.
+func (m *merger) resolve(merged, data interface{}, pointer string) (interface{}, error) {
+	if !equalValues(unwrapSourced(merged, pointer, nil), data) {
+		switch m.conflict {
+		case ConflictFirstWins:
+			return merged, nil
+		case ConflictError:
+			return nil, &MergeError{Pointer: pointer, Source: m.source, Err: ErrConflict}
+		}
+	} else if m.conflict != ConflictLastWins {
+		return merged, nil
+	}
+	return wrapSourced(data, m.source), nil
+}
+
+// indexByKey returns the index of the element of the merged array matching the element,
+// which is the object having the same value for the key, or the one equal to it.
+func indexByKey(merged []interface{}, e interface{}, key string) int {
+	o, ok := e.(map[string]interface{})
+	var value interface{}
+	if ok {
+		value, ok = o[key]
+	}
+	for i, x := range merged {
+		if !ok {
+			if equalValues(unwrapSourced(x, empty, nil), e) {
+				return i
+			}
+			continue
+		}
+		if p, isObject := x.(map[string]interface{}); isObject {
+			if v, found := p[key]; found && equalValues(unwrapSourced(v, empty, nil), value) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// wrapSourced returns a copy of the data with the values, other than the objects and the arrays
+// having data, along with the index of the json tree they came from.
+func wrapSourced(data interface{}, source int) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if len(v) > 0 {
+			m := make(map[string]interface{}, len(v))
+			for k, e := range v {
+				m[k] = wrapSourced(e, source)
+			}
+			return m
+		}
+		data = make(map[string]interface{})
+	case []interface{}:
+		if len(v) > 0 {
+			a := make([]interface{}, len(v))
+			for i, e := range v {
+				a[i] = wrapSourced(e, source)
+			}
+			return a
+		}
+		data = make([]interface{}, 0)
+	}
+	return sourced{data: data, source: source}
+}
+
+// unwrapSourced returns a copy of the merged data at the json pointer, adding the json trees
+// each of the values came from to the sources, in case they are provided.
+func unwrapSourced(merged interface{}, pointer string, sources map[string]int) interface{} {
+	switch v := merged.(type) {
+	case sourced:
+		if sources != nil {
+			sources[pointer] = v.source
+		}
+		return copyData(v.data)
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = unwrapSourced(e, pointer+slash+escapeToken(k), sources)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = unwrapSourced(e, pointer+slash+strconv.Itoa(i), sources)
+		}
+		return a
+	}
+	return merged
+}
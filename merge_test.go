@@ -0,0 +1,163 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+// layers returns the default config, the environment overrides and the tenant overrides.
+func layers(t *testing.T) []*jsonic.Jsonic {
+	all, err := jsonic.New(readFromFile("test_data/test19.json", t))
+	assert.NoError(t, err)
+	docs, err := all.Query("[*]")
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+	return docs
+}
+
+func mustMergedBytes(t *testing.T, j *jsonic.Jsonic) string {
+	b, err := j.Bytes()
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func TestMerge(t *testing.T) {
+	docs := layers(t)
+	merged, sources, err := jsonic.Merge(docs)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"features":["billing"],"labels":{"env":"prod"},"limits":[15,25],`+
+		`"plugins":[{"level":2,"name":"auth"}],"server":{"host":"tenant.example.com","port":9090,"tls":true}}`,
+		mustMergedBytes(t, merged))
+	assert.Equal(t, map[string]int{
+		"/features/0":      2,
+		"/labels/env":      1,
+		"/limits/0":        2,
+		"/limits/1":        2,
+		"/plugins/0/level": 2,
+		"/plugins/0/name":  2,
+		"/server/host":     2,
+		"/server/port":     2,
+		"/server/tls":      1,
+	}, sources)
+
+	// the json trees merged are not modified
+	assert.Equal(t, `{"features":["billing"],"limits":[15,25],"plugins":[{"level":2,"name":"auth"}],`+
+		`"server":{"host":"tenant.example.com","port":9090}}`, mustMergedBytes(t, docs[2]))
+	assert.NoError(t, merged.Set("server.port", 1))
+	assert.Equal(t, 9090.0, mustGet(t, docs[1], "server.port"))
+}
+
+func TestMergeArrays(t *testing.T) {
+	docs := layers(t)
+	merged, sources, err := jsonic.Merge(docs, jsonic.AppendArrays(), jsonic.UnionArraysByKey("name", "plugins"),
+		jsonic.MergeArraysByIndex("limits"))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"login", "search", "search", "export", "billing"}, mustGet(t, merged, "features"))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "auth", "level": 2.0},
+		map[string]interface{}{"name": "cache", "ttl": 300.0},
+		map[string]interface{}{"name": "metrics"},
+	}, mustGet(t, merged, "plugins"))
+	assert.Equal(t, []interface{}{15.0, 25.0, 30.0}, mustGet(t, merged, "limits"))
+	for pointer, source := range map[string]int{
+		"/features/0": 0, "/features/3": 1, "/features/4": 2,
+		"/plugins/0/name": 2, "/plugins/0/level": 2, "/plugins/1/ttl": 1, "/plugins/2/name": 1,
+		"/limits/0": 2, "/limits/1": 2, "/limits/2": 0,
+	} {
+		assert.Equal(t, source, sources[pointer], pointer)
+	}
+
+	// the later options override the earlier ones for the same arrays
+	merged, _, err = jsonic.Merge(docs, jsonic.AppendArrays("features"), jsonic.ReplaceArrays("features"))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"billing"}, mustGet(t, merged, "features"))
+
+	// the paths are resolved on the json tree being merged
+	a := mustNew(t, `{"teams": [{"id": 1, "members": ["a"]}, {"id": 2, "members": ["b"]}]}`)
+	b := mustNew(t, `{"teams": [{"id": 2, "members": ["c"]}, {"id": 3, "members": ["d"]}]}`)
+	merged, sources, err = jsonic.Merge([]*jsonic.Jsonic{a, b}, jsonic.UnionArraysByKey("id", "teams"),
+		jsonic.AppendArrays("teams[*].members"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"teams":[{"id":1,"members":["a"]},{"id":2,"members":["b","c"]},{"id":3,"members":["d"]}]}`,
+		mustMergedBytes(t, merged))
+	assert.Equal(t, 1, sources["/teams/1/members/1"])
+	assert.Equal(t, 1, sources["/teams/2/id"])
+
+	// the elements without the key are matched when equal
+	a = mustNew(t, `[1, {"id": 1, "v": 1}, [2]]`)
+	b = mustNew(t, `[{"id": 1, "v": 2}, 1, [2], {"v": 3}, 4]`)
+	merged, _, err = jsonic.Merge([]*jsonic.Jsonic{a, b}, jsonic.UnionArraysByKey("id"))
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,{"id":1,"v":2},[2],{"v":3},4]`, mustMergedBytes(t, merged))
+}
+
+func TestMergeConflicts(t *testing.T) {
+	docs := layers(t)
+	merged, sources, err := jsonic.Merge(docs, jsonic.OnConflict(jsonic.ConflictFirstWins))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"features":["login","search"],"labels":{"env":"prod"},"limits":[10,20,30],`+
+		`"plugins":[{"level":1,"name":"auth"},{"name":"cache","ttl":60}],"server":{"host":"0.0.0.0","port":8080,"tls":false}}`,
+		mustMergedBytes(t, merged))
+	assert.Equal(t, 0, sources["/server/port"])
+	assert.Equal(t, 1, sources["/labels/env"])
+
+	_, _, err = jsonic.Merge(docs, jsonic.OnConflict(jsonic.ConflictError))
+	var mergeErr *jsonic.MergeError
+	assert.True(t, errors.As(err, &mergeErr))
+	assert.True(t, errors.Is(err, jsonic.ErrConflict))
+	assert.Equal(t, "/features", mergeErr.Pointer)
+	assert.Equal(t, 1, mergeErr.Source)
+	assert.Equal(t, `jsonic: merge of the json tree 1 at "/features": `+jsonic.ErrConflict.Error(), err.Error())
+
+	// the equal data does not conflict
+	a := mustNew(t, `{"a": 1, "b": [1, {"c": null}], "d": {}}`)
+	b := mustNew(t, `{"a": 1.0, "b": [1, {"c": null}], "d": {}, "e": 2}`)
+	merged, sources, err = jsonic.Merge([]*jsonic.Jsonic{a, b}, jsonic.OnConflict(jsonic.ConflictError))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":[1,{"c":null}],"d":{},"e":2}`, mustMergedBytes(t, merged))
+	assert.Equal(t, map[string]int{"/a": 0, "/b/0": 0, "/b/1/c": 0, "/d": 0, "/e": 1}, sources)
+	_, sources, err = jsonic.Merge([]*jsonic.Jsonic{a, b})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"/a": 1, "/b/0": 1, "/b/1/c": 1, "/d": 1, "/e": 1}, sources)
+
+	// the data of different kinds conflicts
+	b = mustNew(t, `{"a": {"x": 1}, "d": []}`)
+	_, _, err = jsonic.Merge([]*jsonic.Jsonic{a, b}, jsonic.OnConflict(jsonic.ConflictError))
+	assert.True(t, errors.As(err, &mergeErr))
+	assert.Equal(t, "/a", mergeErr.Pointer)
+	merged, sources, err = jsonic.Merge([]*jsonic.Jsonic{a, b})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"x":1},"b":[1,{"c":null}],"d":[]}`, mustMergedBytes(t, merged))
+	assert.Equal(t, 1, sources["/a/x"])
+	assert.Equal(t, 1, sources["/d"])
+}
+
+func TestMergeEdgeCases(t *testing.T) {
+	merged, sources, err := jsonic.Merge(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "null", mustMergedBytes(t, merged))
+	assert.Empty(t, sources)
+
+	// the child json trees are merged with the json pointers relative to them
+	j := mustNew(t, `{"x": {"a": [1]}, "y": {"a": [2], "b": 1}}`)
+	merged, sources, err = jsonic.Merge([]*jsonic.Jsonic{mustChild(t, j, "x"), mustChild(t, j, "y")},
+		jsonic.AppendArrays("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[1,2],"b":1}`, mustMergedBytes(t, merged))
+	assert.Equal(t, map[string]int{"/a/0": 0, "/a/1": 1, "/b": 1}, sources)
+
+	// the options of the first json tree are used
+	a, err := jsonic.NewWithOptions([]byte(`{"n": 9007199254740993}`), jsonic.UseNumber(), jsonic.Lazy())
+	assert.NoError(t, err)
+	merged, _, err = jsonic.Merge([]*jsonic.Jsonic{a, mustNew(t, `{"m": 1}`)})
+	assert.NoError(t, err)
+	n, err := merged.GetInt64("n")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), n)
+
+	_, _, err = jsonic.Merge([]*jsonic.Jsonic{a, a}, jsonic.AppendArrays(`a["`))
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+}
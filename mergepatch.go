@@ -0,0 +1,73 @@
+package jsonic
+
+// MergePatch returns a new json tree with the json merge patch, as per RFC 7396,
+// applied on this json tree.
+//
+// The objects in the patch are merged recursively, where a null value removes
+// the key, and any other value, including an array, replaces the existing one.
+//
+// This json tree is not modified.
+func (j *Jsonic) MergePatch(patch *Jsonic) *Jsonic {
+	return new(mergePatch(copyData(j.value()), patch.value()), j.opts)
+}
+
+// CreateMergePatch returns the json merge patch, as per RFC 7396, which can be applied
+// on the original json tree to get the modified one.
+//
+// As null in the patch removes the key, a key whose value is changed to null
+// in the modified json tree cannot be expressed, and it is removed instead.
+func CreateMergePatch(original, modified *Jsonic) *Jsonic {
+	return new(mergePatchOf(original.value(), modified.value()), modified.opts)
+}
+
+// mergePatch applies the patch on the target, which is modified.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return copyData(patch)
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{}, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+// mergePatchOf returns the patch to get the modified data from the original data.
+func mergePatchOf(original, modified interface{}) interface{} {
+	o, okO := original.(map[string]interface{})
+	m, okM := modified.(map[string]interface{})
+	if !okO || !okM {
+		return copyData(modified)
+	}
+	patch := make(map[string]interface{})
+	for k := range o {
+		if _, ok := m[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, v := range m {
+		w, ok := o[k]
+		if !ok {
+			patch[k] = copyData(v)
+			continue
+		}
+		if equalValues(v, w) {
+			continue
+		}
+		_, isObject := w.(map[string]interface{})
+		if _, ok := v.(map[string]interface{}); ok && isObject {
+			patch[k] = mergePatchOf(w, v)
+			continue
+		}
+		patch[k] = copyData(v)
+	}
+	return patch
+}
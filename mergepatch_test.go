@@ -0,0 +1,72 @@
+package jsonic_test
+
+import (
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	vectors, err := jsonic.New(readFromFile("test_data/test17.json", t))
+	assert.NoError(t, err)
+	all, err := vectors.Query("[*]")
+	assert.NoError(t, err)
+	assert.Len(t, all, 16)
+	for _, v := range all {
+		original := mustChild(t, v, "original")
+		patch := mustChild(t, v, "patch")
+		expected := mustBytes(t, v, "result")
+		before := mustBytes(t, v, "original")
+
+		b, err := original.MergePatch(patch).Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(b), string(before))
+		// the original is not modified
+		assert.Equal(t, string(before), string(mustBytes(t, v, "original")))
+
+		// the patch created gives the same result
+		created := jsonic.CreateMergePatch(original, mustChild(t, v, "result"))
+		b, err = original.MergePatch(created).Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(b), string(before))
+	}
+}
+
+func TestMergePatchIndependent(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": {"b": 1}}`))
+	assert.NoError(t, err)
+	patch, err := jsonic.New([]byte(`{"a": {"c": {"d": 2}}, "e": [1]}`))
+	assert.NoError(t, err)
+	merged := j.MergePatch(patch)
+	assert.NoError(t, merged.Set("a.c.d", 3))
+	assert.NoError(t, merged.Append("e", 2))
+	// neither the json tree nor the patch are modified
+	assert.Equal(t, `{"a":{"b":1}}`, string(mustBytes(t, j, ".")))
+	assert.Equal(t, `{"a":{"c":{"d":2}},"e":[1]}`, string(mustBytes(t, patch, ".")))
+	assert.Equal(t, `{"a":{"b":1,"c":{"d":3}},"e":[1,2]}`, string(mustBytes(t, merged, ".")))
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	cases := []struct {
+		original, modified, patch string
+	}{
+		{`{"a": 1}`, `{"a": 1.0}`, `{}`},
+		{`{"a": {"b": 1, "c": [1]}, "d": 1}`, `{"a": {"b": 1, "c": [1, 2]}, "e": {"f": null}}`,
+			`{"a":{"c":[1,2]},"d":null,"e":{"f":null}}`},
+		{`{"a": {"b": {"c": 1, "d": 2}}}`, `{"a": {"b": {"c": 1}}}`, `{"a":{"b":{"d":null}}}`},
+		{`{"a": 1}`, `[1]`, `[1]`},
+		{`[1]`, `{"a": 1}`, `{"a":1}`},
+		// null cannot be set, so the key is removed
+		{`{"a": 1}`, `{"a": null}`, `{"a":null}`},
+	}
+	for _, c := range cases {
+		original, err := jsonic.New([]byte(c.original))
+		assert.NoError(t, err)
+		modified, err := jsonic.New([]byte(c.modified))
+		assert.NoError(t, err)
+		b, err := jsonic.CreateMergePatch(original, modified).Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, c.patch, string(b), c.original+" "+c.modified)
+	}
+}
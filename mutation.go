@@ -0,0 +1,203 @@
+package jsonic
+
+import (
+	"strconv"
+)
+
+// Set is used to set the value at the path specified.
+//
+// The path is resolved in the same way as it is done in Child, so in case
+// there is already some data at the path, it is replaced by the value.
+// Otherwise, the missing objects along the path are created, and the value
+// is added to them. An array can only be extended by setting the value
+// at the index equal to its length.
+//
+// The value can be of any type that can be marshalled to json,
+// including another Jsonic, whose data is copied.
+// The path should not select multiple json trees, like the ones with
+// wildcards do, otherwise ErrInvalidPath is returned.
+// Note that mutating the json tree is not safe for concurrent use.
+func (j *Jsonic) Set(path string, value interface{}) error {
+	data, err := j.opts.normalize(value)
+	if err != nil {
+		return err
+	}
+	if path == dot || path == empty {
+		j.getDotOrEmptyChild(path).update(data)
+		return nil
+	}
+	elements, err := parseSingularPath(path)
+	if err != nil {
+		return err
+	}
+	return j.set(elements, data)
+}
+
+// Delete is used to remove the data at the path specified from the json tree.
+//
+// In case of an array, the elements following the one deleted are shifted.
+func (j *Jsonic) Delete(path string) error {
+	child, err := j.singularChild(path)
+	if err != nil {
+		return err
+	}
+	parent := child.parent
+	if parent == nil {
+		return ErrDeleteRoot
+	}
+	switch container := parent.shallow().(type) {
+	case map[string]interface{}:
+		delete(container, child.key)
+		parent.removeFromCache(child.key)
+	case []interface{}:
+		index, _ := strconv.Atoi(child.key)
+		updated := make([]interface{}, 0, len(container)-1)
+		updated = append(updated, container[:index]...)
+		updated = append(updated, container[index+1:]...)
+		parent.update(updated)
+	}
+	return nil
+}
+
+// Insert is used to insert the value in the array at the path specified.
+//
+// The index should lie between 0 and the length of the array, both inclusive,
+// and the elements starting from the index are shifted.
+func (j *Jsonic) Insert(path string, index int, value interface{}) error {
+	child, array, err := j.childArray(path)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index > len(array) {
+		return ErrIndexOutOfBound
+	}
+	data, err := j.opts.normalize(value)
+	if err != nil {
+		return err
+	}
+	updated := make([]interface{}, 0, len(array)+1)
+	updated = append(updated, array[:index]...)
+	updated = append(updated, data)
+	updated = append(updated, array[index:]...)
+	child.update(updated)
+	return nil
+}
+
+// Append is used to add the value at the end of the array at the path specified.
+func (j *Jsonic) Append(path string, value interface{}) error {
+	child, array, err := j.childArray(path)
+	if err != nil {
+		return err
+	}
+	data, err := j.opts.normalize(value)
+	if err != nil {
+		return err
+	}
+	// the existing elements keep their indices, so the cache is still valid
+	child.setData(append(array, data))
+	return nil
+}
+
+func (j *Jsonic) set(path []pathElement, data interface{}) error {
+	if child, err := j.child(path); err == nil {
+		// something already exists here, so just replace it
+		child.update(data)
+		return nil
+	}
+	last := len(path) - 1
+	parent, err := j.child(path[:last])
+	if err != nil {
+		// the parent does not exist either, so create it as an array
+		// in case an index is to be set, otherwise as an object
+		var container interface{} = make(map[string]interface{})
+		if path[last].nature == natureIndex {
+			container = make([]interface{}, 0)
+		}
+		err = j.set(path[:last], container)
+		if err != nil {
+			return err
+		}
+		parent, err = j.child(path[:last])
+		if err != nil {
+			return err
+		}
+	}
+	return parent.setChild(path[last], data)
+}
+
+func (j *Jsonic) setChild(element pathElement, data interface{}) error {
+	switch container := j.shallow().(type) {
+	case map[string]interface{}:
+		container[element.key] = data
+		j.removeFromCache(element.key)
+		return nil
+	case []interface{}:
+		// only the index next to the last element can be set here
+		_, err := element.indexIn(len(container) + 1)
+		if err != nil {
+			return err
+		}
+		j.setData(append(container, data))
+		return nil
+	}
+	return ErrUnexpectedJSONData
+}
+
+func (j *Jsonic) childArray(path string) (*Jsonic, []interface{}, error) {
+	child, err := j.singularChild(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	array, ok := child.shallow().([]interface{})
+	if !ok {
+		return nil, nil, ErrInvalidType
+	}
+	return child, array, nil
+}
+
+func (j *Jsonic) singularChild(path string) (*Jsonic, error) {
+	if path == dot || path == empty {
+		return j.getDotOrEmptyChild(path), nil
+	}
+	elements, err := parseSingularPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return j.child(elements)
+}
+
+// parseSingularPath parses the path, which should not select multiple json trees.
+func parseSingularPath(path string) ([]pathElement, error) {
+	elements, err := parsePathOrPointer(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, element := range elements {
+		if !element.singular() {
+			return nil, ErrInvalidPath
+		}
+	}
+	return elements, nil
+}
+
+// update replaces the data, discarding the children resolved from the older one.
+func (j *Jsonic) update(data interface{}) {
+	j.setData(data)
+	j.resetCache()
+}
+
+// setData replaces the data, and makes sure the parent refers to the new one.
+func (j *Jsonic) setData(data interface{}) {
+	j.data = data
+	if j.parent == nil {
+		return
+	}
+	switch container := j.parent.data.(type) {
+	case map[string]interface{}:
+		container[j.key] = data
+	case []interface{}:
+		if index, err := strconv.Atoi(j.key); err == nil && index < len(container) {
+			container[index] = data
+		}
+	}
+}
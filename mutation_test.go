@@ -0,0 +1,185 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	// replace existing, with the same preference as the getters
+	s, err := j.GetString("a.x")
+	assert.NoError(t, err)
+	assert.Equal(t, "p", s)
+	err = j.Set("a.x", "updated")
+	assert.NoError(t, err)
+	s, err = j.GetString("a.x")
+	assert.NoError(t, err)
+	assert.Equal(t, "updated", s)
+	m, err := j.GetStringMap("a.x")
+	assert.Error(t, err)
+	assert.Nil(t, m)
+	s, err = j.GetString("a.x.y")
+	assert.NoError(t, err)
+	assert.Equal(t, "q", s)
+
+	// add to an existing object
+	err = j.Set("a.arr.[0].c.d.g", 1)
+	assert.NoError(t, err)
+	i, err := j.GetInt("a.arr.[0].c.d.g")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	// create the missing objects
+	err = j.Set("m.n.o", true)
+	assert.NoError(t, err)
+	b, err := j.GetBool("m.n.o")
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	// extend an array
+	err = j.Set("a.arr.[1]", map[string]string{"a": "c"})
+	assert.NoError(t, err)
+	s, err = j.GetString("a.arr.[1].a")
+	assert.NoError(t, err)
+	assert.Equal(t, "c", s)
+
+	// array index out of bound
+	err = j.Set("a.arr.[5]", 1)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrIndexOutOfBound))
+
+	// not a container
+	err = j.Set("c.e", 1)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrUnexpectedJSONData))
+
+	// invalid value
+	err = j.Set("c", func() {})
+	assert.Error(t, err)
+
+	// another jsonic as value
+	o, err := jsonic.New([]byte(`{"naruto": "rocks"}`))
+	assert.NoError(t, err)
+	err = j.Set("c", o)
+	assert.NoError(t, err)
+	s, err = j.GetString("c.naruto")
+	assert.NoError(t, err)
+	assert.Equal(t, "rocks", s)
+
+	// root
+	err = j.Set(".", []int{1, 2})
+	assert.NoError(t, err)
+	iArr, err := j.GetIntArray(".")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, iArr)
+}
+
+func TestSetFromChild(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test2.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	c, err := j.Child("e")
+	assert.NoError(t, err)
+	err = c.Set(".", []string{"naruto"})
+	assert.NoError(t, err)
+	s, err := j.GetStringArray("e")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"naruto"}, s)
+
+	c, err = j.Child("i")
+	assert.NoError(t, err)
+	err = c.Set("boruto", 2)
+	assert.NoError(t, err)
+	i, err := j.GetIntMap("i")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"naruto": 1, "boruto": 2}, i)
+}
+
+func TestDelete(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test2.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	// object key
+	i, err := j.GetInt("a")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+	err = j.Delete("a")
+	assert.NoError(t, err)
+	i, err = j.GetInt("a")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Equal(t, 0, i)
+
+	// array element
+	s, err := j.GetString("h.[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, "boruto", s)
+	err = j.Delete("h.[0]")
+	assert.NoError(t, err)
+	s, err = j.GetString("h.[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, "boruto", s)
+	sArr, err := j.GetStringArray("h")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"boruto"}, sArr)
+
+	// not found
+	err = j.Delete("z")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+
+	// root
+	err = j.Delete(".")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrDeleteRoot))
+}
+
+func TestInsertAndAppend(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test2.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i, err := j.GetInt("e.[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	err = j.Insert("e", 0, 0)
+	assert.NoError(t, err)
+	err = j.Insert("e", 2, 5)
+	assert.NoError(t, err)
+	err = j.Append("e", 3)
+	assert.NoError(t, err)
+	iArr, err := j.GetIntArray("e")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 5, 2, 3}, iArr)
+	i, err = j.GetInt("e.[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, i)
+
+	// index out of bound
+	err = j.Insert("e", 6, 0)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrIndexOutOfBound))
+
+	// not an array
+	err = j.Insert("a", 0, 0)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+	err = j.Append("i", 0)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidType))
+
+	// not found
+	err = j.Append("z", 0)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+}
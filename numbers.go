@@ -0,0 +1,226 @@
+package jsonic
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// toInt64 converts the json number to an integer of the bit size provided.
+//
+// The number should be an integer, otherwise ErrPrecision is returned instead of
+// truncating it, and in case it does not fit in the bit size, ErrOverflow is returned
+// instead of an incorrect result. The json numbers are checked exactly, without going
+// through a floating point number.
+func toInt64(val interface{}, bitSize int) (int64, error) {
+	switch v := val.(type) {
+	case float64:
+		return floatToInt64(v, bitSize)
+	case json.Number:
+		return literalToInt64(string(v), bitSize)
+	}
+	return 0, ErrInvalidType
+}
+
+// toUint64 converts the json number to an unsigned integer of the bit size provided.
+//
+// Same as toInt64, the numbers with a fractional part return ErrPrecision, and the
+// negative numbers are reported as an overflow.
+func toUint64(val interface{}, bitSize int) (uint64, error) {
+	switch v := val.(type) {
+	case float64:
+		return floatToUint64(v, bitSize)
+	case json.Number:
+		return literalToUint64(string(v), bitSize)
+	}
+	return 0, ErrInvalidType
+}
+
+func literalToInt64(s string, bitSize int) (int64, error) {
+	u, negative, err := integerOf(s)
+	if err != nil {
+		return 0, err
+	}
+	limit := uint64(1) << uint(bitSize-1)
+	if negative {
+		if u > limit {
+			return 0, ErrOverflow
+		}
+		// the negation wraps around for the smallest integer, giving the correct result
+		return -int64(u), nil
+	}
+	if u >= limit {
+		return 0, ErrOverflow
+	}
+	return int64(u), nil
+}
+
+func literalToUint64(s string, bitSize int) (uint64, error) {
+	u, negative, err := integerOf(s)
+	if err != nil {
+		return 0, err
+	}
+	if (negative && u != 0) || (bitSize < 64 && u >= uint64(1)<<uint(bitSize)) {
+		return 0, ErrOverflow
+	}
+	return u, nil
+}
+
+// integerOf returns the magnitude and the sign of the json number, only if it is an integer.
+//
+// It returns ErrPrecision in case the number has a fractional part, and ErrOverflow in case
+// it does not fit in an unsigned integer, without expanding the exponent.
+func integerOf(s string) (uint64, bool, error) {
+	if !isNumberLiteral(s) {
+		return 0, false, ErrInvalidType
+	}
+	negative := s[0] == '-'
+	if negative {
+		s = s[1:]
+	}
+	mantissa, exponent := s, empty
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent = s[:i], s[i+1:]
+	}
+	whole, fraction := mantissa, empty
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		whole, fraction = mantissa[:i], mantissa[i+1:]
+	}
+	// the digits are the ones of the whole part followed by the ones of the fraction
+	digit := func(k int) byte {
+		if k < len(whole) {
+			return whole[k]
+		}
+		return fraction[k-len(whole)]
+	}
+	first, last := 0, len(whole)+len(fraction)
+	for first < last && digit(first) == '0' {
+		first++
+	}
+	for last > first && digit(last-1) == '0' {
+		last--
+	}
+	if first == last {
+		return 0, negative, nil
+	}
+	// the position of the decimal point in the digits
+	point := len(whole)
+	if exponent != empty {
+		e, err := strconv.Atoi(exponent)
+		switch {
+		case (err != nil || e < -math.MaxInt32) && exponent[0] == '-':
+			return 0, false, ErrPrecision
+		case err != nil || e > math.MaxInt32:
+			return 0, false, ErrOverflow
+		}
+		point += e
+	}
+	switch {
+	case point < last:
+		return 0, false, ErrPrecision
+	case point-first > 20:
+		// more digits than the largest unsigned integer
+		return 0, false, ErrOverflow
+	}
+	var u uint64
+	for k := first; k < point; k++ {
+		d := uint64(0)
+		if k < last {
+			d = uint64(digit(k) - '0')
+		}
+		if u > (math.MaxUint64-d)/10 {
+			return 0, false, ErrOverflow
+		}
+		u = u*10 + d
+	}
+	return u, negative, nil
+}
+
+// toFloat64 converts the json number to a floating point number of the bit size provided.
+func toFloat64(val interface{}, bitSize int) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		if bitSize == 32 && math.Abs(v) > math.MaxFloat32 {
+			return 0, ErrOverflow
+		}
+		return v, nil
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), bitSize)
+		if err != nil {
+			if isRangeError(err) {
+				return 0, ErrOverflow
+			}
+			return 0, ErrInvalidType
+		}
+		return f, nil
+	}
+	return 0, ErrInvalidType
+}
+
+func floatToInt64(f float64, bitSize int) (int64, error) {
+	// the limits are powers of 2, so they are represented exactly
+	limit := math.Ldexp(1, bitSize-1)
+	if math.IsNaN(f) || f >= limit || f < -limit {
+		return 0, ErrOverflow
+	}
+	if f != math.Trunc(f) {
+		return 0, ErrPrecision
+	}
+	return int64(f), nil
+}
+
+func floatToUint64(f float64, bitSize int) (uint64, error) {
+	limit := math.Ldexp(1, bitSize)
+	if math.IsNaN(f) || f >= limit || f <= -1 {
+		return 0, ErrOverflow
+	}
+	if f != math.Trunc(f) {
+		return 0, ErrPrecision
+	}
+	return uint64(f), nil
+}
+
+func isRangeError(err error) bool {
+	numErr, ok := err.(*strconv.NumError)
+	return ok && numErr.Err == strconv.ErrRange
+}
+
+// isNumberLiteral reports whether the string is a number as per the json grammar.
+func isNumberLiteral(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	digits := i
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	if i == digits || (s[digits] == '0' && i-digits > 1) {
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		fraction := i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == fraction {
+			return false
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		exponent := i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == exponent {
+			return false
+		}
+	}
+	return i == len(s)
+}
@@ -0,0 +1,179 @@
+package jsonic_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseNumber(t *testing.T) {
+	j, err := jsonic.NewWithOptions(readFromFile("test_data/test4.json", t), jsonic.UseNumber())
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i64, err := j.GetInt64("a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), i64)
+
+	i64, err = j.GetInt64("b")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9223372036854775807), i64)
+
+	i64, err = j.GetInt64("c")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Equal(t, int64(0), i64)
+
+	i, err := j.GetInt("d")
+	assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+	assert.Equal(t, 0, i)
+
+	i, err = j.GetInt("e")
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, i)
+
+	i, err = j.GetInt("f")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+
+	f64, err := j.GetFloat64("d")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f64)
+
+	f64, err = j.GetFloat64("f")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Equal(t, 0.0, f64)
+
+	f, err := j.GetFloat("g")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Equal(t, float32(0), f)
+
+	f64, err = j.GetFloat64("g")
+	assert.NoError(t, err)
+	assert.Equal(t, 3.5e38, f64)
+
+	i64Arr, err := j.GetInt64Array("h")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{9007199254740993, 0, 2}, i64Arr)
+
+	i64Arr, err = j.GetInt64Array("i")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Nil(t, i64Arr)
+
+	i64Map, err := j.GetInt64Map("j")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"naruto": 9007199254740993}, i64Map)
+
+	f64Map, err := j.GetFloat64Map("k")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Nil(t, f64Map)
+
+	// the numbers are kept as is while encoding
+	c, err := j.Child("a")
+	assert.NoError(t, err)
+	b, err := c.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, "9007199254740993", string(b))
+
+	// and while setting
+	err = j.Set("l", uint64(18446744073709551615))
+	assert.NoError(t, err)
+	v, err := j.Get("l")
+	assert.NoError(t, err)
+	assert.Equal(t, "18446744073709551615", fmt.Sprint(v))
+}
+
+func TestNumbersOverflow(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": 9223372036854775808, "b": 1e300, "c": [1e300], "d": 2.5}`))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i64, err := j.GetInt64("a")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Equal(t, int64(0), i64)
+
+	f, err := j.GetFloat("b")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Equal(t, float32(0), f)
+
+	fArr, err := j.GetFloatArray("c")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	assert.Nil(t, fArr)
+
+	i, err := j.GetInt("d")
+	assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+	assert.Equal(t, 0, i)
+	_, err = j.GetInt64("d")
+	assert.True(t, errors.Is(err, jsonic.ErrPrecision))
+}
+
+func TestNumbersPrecision(t *testing.T) {
+	data := []byte(`{"a": 1.0, "b": 12.5e1, "c": 1.25e1, "d": 0.5, "e": -0e-9, "f": 1e-400, "g": 1e19,
+		"h": 9007199254740993.0000000001, "i": 0.0e-999999999999999999999, "j": 1e-999999999999999999999, "k": -2E+2}`)
+	for _, opts := range [][]jsonic.Option{nil, {jsonic.UseNumber()}} {
+		j, err := jsonic.NewWithOptions(data, opts...)
+		assert.NoError(t, err)
+		for path, expected := range map[string]int64{"a": 1, "b": 125, "e": 0, "i": 0, "k": -200} {
+			i64, err := j.GetInt64(path)
+			assert.NoError(t, err, path)
+			assert.Equal(t, expected, i64, path)
+		}
+		for _, path := range []string{"c", "d"} {
+			_, err := j.GetInt64(path)
+			assert.True(t, errors.Is(err, jsonic.ErrPrecision), path)
+			_, err = j.GetIntArray(path)
+			assert.Error(t, err, path)
+		}
+		_, err = j.GetInt64("g")
+		assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	}
+
+	// the json numbers are checked exactly, without rounding them
+	j, err := jsonic.NewWithOptions(data, jsonic.UseNumber())
+	assert.NoError(t, err)
+	for path, expected := range map[string]error{"f": jsonic.ErrPrecision, "h": jsonic.ErrPrecision,
+		"j": jsonic.ErrPrecision} {
+		_, err = j.GetInt64(path)
+		assert.True(t, errors.Is(err, expected), path)
+	}
+	j, err = jsonic.NewWithOptions([]byte(`[1e999999999999999999999, -9223372036854775808, -9223372036854775809,
+		18446744073709551615, 18446744073709551616, -0.0]`), jsonic.UseNumber())
+	assert.NoError(t, err)
+	_, err = j.GetInt64("[0]")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	i64, err := j.GetInt64("[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-9223372036854775808), i64)
+	_, err = j.GetInt64("[2]")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	u64, err := jsonic.GetAs[uint64](j, "[3]")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(18446744073709551615), u64)
+	_, err = jsonic.GetAs[uint64](j, "[4]")
+	assert.True(t, errors.Is(err, jsonic.ErrOverflow))
+	u64, err = jsonic.GetAs[uint64](j, "[5]")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), u64)
+}
+
+func TestNewWithOptionsError(t *testing.T) {
+	j, err := jsonic.NewWithOptions(nil, jsonic.UseNumber())
+	assert.Nil(t, j)
+	assert.Error(t, err)
+	assert.Equal(t, "unexpected end of JSON input", err.Error())
+
+	j, err = jsonic.NewWithOptions([]byte(`{"a": 1} x`), jsonic.UseNumber())
+	assert.Nil(t, j)
+	assert.Error(t, err)
+	assert.Equal(t, "invalid character 'x' after top-level value", err.Error())
+}
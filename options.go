@@ -0,0 +1,127 @@
+package jsonic
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// Option is used to configure the way the json data is parsed and queried.
+type Option func(*options)
+
+type options struct {
+	useNumber          bool
+	elements           int
+	fallbackOnMismatch bool
+	lazy               bool
+	skipMalformed      bool
+	malformed          func(err error)
+}
+
+// ways to handle the elements not matching the expected type in the typed arrays and maps
+const (
+	elementsDefault = iota
+	elementsStrict
+	elementsSkip
+)
+
+// UseNumber is used to keep the numbers in the json data as json.Number
+// instead of converting them to float64, so that no precision is lost.
+//
+// With this, the integers beyond 2^53 can be retrieved exactly.
+func UseNumber() Option {
+	return func(o *options) {
+		o.useNumber = true
+	}
+}
+
+// StrictElements is used to make the typed array and map utilities, like GetIntArray
+// and GetStringMap, fail in case any of the elements does not match the expected type.
+// The error returned names the index or the key of such an element.
+//
+// By default, such an element is set to the zero value in the arrays, and
+// skipped in the maps.
+func StrictElements() Option {
+	return func(o *options) {
+		o.elements = elementsStrict
+	}
+}
+
+// SkipElements is used to make the typed array and map utilities, like GetIntArray
+// and GetStringMap, skip the elements not matching the expected type, instead of
+// setting them to the zero value in the arrays.
+func SkipElements() Option {
+	return func(o *options) {
+		o.elements = elementsSkip
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) unmarshal(data []byte) (interface{}, error) {
+	var unmarshalled interface{}
+	if !o.useNumber {
+		err := json.Unmarshal(data, &unmarshalled)
+		if err != nil {
+			return nil, err
+		}
+		return unmarshalled, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&unmarshalled)
+	if err == nil {
+		// make sure there is nothing after the json value
+		_, err = decoder.Token()
+		if err == io.EOF {
+			return unmarshalled, nil
+		}
+	}
+	// let the standard library report the problem, so that it is same in both the modes
+	var discarded interface{}
+	return nil, json.Unmarshal(data, &discarded)
+}
+
+// normalize converts the value into the same form as the unmarshalled json data.
+func (o *options) normalize(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, bool, string:
+		return v, nil
+	case *Jsonic:
+		value = v.value()
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return o.unmarshal(b)
+}
+
+// failElement reports whether the error converting an element of the typed arrays and maps should be returned.
+func (o *options) failElement(err error) bool {
+	// a number is never truncated
+	return err == ErrOverflow || err == ErrPrecision || o.elements == elementsStrict
+}
+
+// fallback reports whether the default value should be used instead of returning the error.
+func (o *options) fallback(err error) bool {
+	e, ok := err.(*PathError)
+	if !ok {
+		return false
+	}
+	switch e.Err {
+	case ErrNoDataFound, ErrIndexNotFound, ErrIndexOutOfBound, ErrUnexpectedJSONData:
+		// nothing at the path
+		return true
+	case ErrInvalidPath:
+		return false
+	}
+	// the data is of an unexpected type, where null is same as nothing
+	return (e.Actual == KindNull && e.Element == empty) || o.fallbackOnMismatch
+}
@@ -0,0 +1,356 @@
+package jsonic
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// the operations of the json patch
+const (
+	opAdd     = "add"
+	opRemove  = "remove"
+	opReplace = "replace"
+	opMove    = "move"
+	opCopy    = "copy"
+	opTest    = "test"
+)
+
+// ApplyPatch returns a new json tree with the json patch, as per RFC 6902, applied on this json tree.
+//
+// The patch is a json array of the operations add, remove, replace, move, copy and test,
+// where each of the paths is a json pointer. The operations are applied one after the
+// other, and in case any of them fails, nothing is applied, and the error returned is
+// a *PatchError naming the operation. A test operation failing returns ErrTestFailed.
+//
+// This json tree is not modified.
+func (j *Jsonic) ApplyPatch(patch []byte) (*Jsonic, error) {
+	data, err := j.opts.unmarshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	operations, ok := data.([]interface{})
+	if !ok {
+		return nil, ErrInvalidPatch
+	}
+	result := new(copyData(j.value()), j.opts)
+	for i, operation := range operations {
+		op, path, err := result.applyOperation(operation)
+		if err != nil {
+			return nil, &PatchError{Operation: i, Op: op, Path: path, Err: err}
+		}
+	}
+	return result, nil
+}
+
+// CreatePatch returns the json patch, as per RFC 6902, which can be applied on the first json tree
+// to get the other one.
+//
+// The objects are compared key by key. The arrays are compared after leaving out the elements they
+// have in common at the beginning and the end, using the fewest operations adding, removing and
+// replacing the elements, where the elements replaced are patched in turn. The arrays so large that
+// finding the fewest operations takes too much memory are compared element by element instead.
+func CreatePatch(a, b *Jsonic) ([]byte, error) {
+	operations := patchOperations(empty, a.value(), b.value(), make([]interface{}, 0))
+	e := newEncoder(nil)
+	err := e.encode(operations, 0)
+	if err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+// applyOperation applies the operation of the json patch, returning its name and path.
+func (j *Jsonic) applyOperation(operation interface{}) (string, string, error) {
+	o, ok := operation.(map[string]interface{})
+	if !ok {
+		return empty, empty, ErrInvalidPatch
+	}
+	op, _ := o["op"].(string)
+	path, ok := o["path"].(string)
+	if !ok {
+		return op, path, ErrInvalidPatch
+	}
+	value, hasValue := o["value"]
+	from, hasFrom := o["from"].(string)
+	switch {
+	case (op == opAdd || op == opReplace || op == opTest) && !hasValue,
+		(op == opMove || op == opCopy) && !hasFrom:
+		return op, path, ErrInvalidPatch
+	}
+	var err error
+	switch op {
+	case opAdd:
+		err = j.patchAdd(path, value)
+	case opRemove:
+		_, err = j.patchRemove(path)
+	case opReplace:
+		err = j.patchReplace(path, value)
+	case opMove:
+		if from == path {
+			_, err = j.patchGet(from)
+			break
+		}
+		if strings.HasPrefix(path, from+slash) {
+			// cannot be moved into one of its own children
+			return op, path, ErrInvalidPatch
+		}
+		value, err = j.patchRemove(from)
+		if err == nil {
+			err = j.patchAdd(path, value)
+		}
+	case opCopy:
+		value, err = j.patchGet(from)
+		if err == nil {
+			err = j.patchAdd(path, copyData(value))
+		}
+	case opTest:
+		var actual interface{}
+		actual, err = j.patchGet(path)
+		if err == nil && !equalValues(actual, value) {
+			err = ErrTestFailed
+		}
+	default:
+		err = ErrInvalidPatch
+	}
+	return op, path, err
+}
+
+// patchChild returns the json tree at the json pointer.
+func (j *Jsonic) patchChild(pointer string) (*Jsonic, error) {
+	if pointer == empty {
+		return j, nil
+	}
+	if !strings.HasPrefix(pointer, slash) {
+		return nil, ErrInvalidPath
+	}
+	return compilePath(pointer).Child(j)
+}
+
+// patchGet returns the data at the json pointer.
+func (j *Jsonic) patchGet(pointer string) (interface{}, error) {
+	child, err := j.patchChild(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return child.value(), nil
+}
+
+// patchAdd adds the data at the json pointer, where the object or the array
+// containing it should already exist.
+func (j *Jsonic) patchAdd(pointer string, data interface{}) error {
+	if pointer == empty {
+		j.update(data)
+		return nil
+	}
+	if !strings.HasPrefix(pointer, slash) {
+		return ErrInvalidPath
+	}
+	last := strings.LastIndex(pointer, slash)
+	token, err := unescapeToken(pointer[last+1:])
+	if err != nil {
+		return err
+	}
+	parent, err := j.patchChild(pointer[:last])
+	if err != nil {
+		return err
+	}
+	switch container := parent.shallow().(type) {
+	case map[string]interface{}:
+		container[token] = data
+		parent.removeFromCache(token)
+	case []interface{}:
+		index := len(container)
+		if token != "-" {
+			// the elements starting from the index are shifted
+			index, err = tokenIndex(token)
+			if err != nil {
+				return err
+			}
+			if index > len(container) {
+				return ErrIndexOutOfBound
+			}
+		}
+		updated := make([]interface{}, 0, len(container)+1)
+		updated = append(updated, container[:index]...)
+		updated = append(updated, data)
+		updated = append(updated, container[index:]...)
+		parent.update(updated)
+	default:
+		return ErrUnexpectedJSONData
+	}
+	return nil
+}
+
+// patchRemove removes the data at the json pointer, returning it.
+func (j *Jsonic) patchRemove(pointer string) (interface{}, error) {
+	if pointer == empty {
+		return nil, ErrDeleteRoot
+	}
+	data, err := j.patchGet(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return data, j.Delete(pointer)
+}
+
+// patchReplace replaces the data at the json pointer, which should already exist.
+func (j *Jsonic) patchReplace(pointer string, data interface{}) error {
+	child, err := j.patchChild(pointer)
+	if err != nil {
+		return err
+	}
+	child.update(data)
+	return nil
+}
+
+// patchOperations adds the operations to get the data b from the data a at the json pointer.
+func patchOperations(pointer string, a, b interface{}, operations []interface{}) []interface{} {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		if y, ok := b.(map[string]interface{}); ok {
+			return objectPatchOperations(pointer, x, y, operations)
+		}
+	case []interface{}:
+		if y, ok := b.([]interface{}); ok {
+			return arrayPatchOperations(pointer, x, y, operations)
+		}
+	}
+	if equalValues(a, b) {
+		return operations
+	}
+	return append(operations, patchOperation(opReplace, pointer, b))
+}
+
+func objectPatchOperations(pointer string, a, b map[string]interface{}, operations []interface{}) []interface{} {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		x, inA := a[k]
+		y, inB := b[k]
+		path := pointer + slash + escapeToken(k)
+		switch {
+		case !inB:
+			operations = append(operations, patchOperation(opRemove, path, nil))
+		case !inA:
+			operations = append(operations, patchOperation(opAdd, path, y))
+		default:
+			operations = patchOperations(path, x, y, operations)
+		}
+	}
+	return operations
+}
+
+// maxArrayDistances is the largest number of the edit distances computed while comparing the arrays,
+// beyond which the elements are compared index by index instead.
+const maxArrayDistances = 1 << 20
+
+func arrayPatchOperations(pointer string, a, b []interface{}, operations []interface{}) []interface{} {
+	// the elements in common at the beginning and the end are left as they are
+	start := 0
+	for start < len(a) && start < len(b) && equalValues(a[start], b[start]) {
+		start++
+	}
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && equalValues(a[endA-1], b[endB-1]) {
+		endA--
+		endB--
+	}
+	a, b = a[start:endA], b[start:endB]
+	if (len(a)+1)*(len(b)+1) > maxArrayDistances {
+		return indexPatchOperations(pointer, start, a, b, operations)
+	}
+	// the distance at i, j is the fewest operations to get b[j:] from a[i:],
+	// where replacing an element is a single operation
+	width := len(b) + 1
+	distances := make([]int, (len(a)+1)*width)
+	for i := len(a); i >= 0; i-- {
+		for j := len(b); j >= 0; j-- {
+			switch {
+			case i == len(a):
+				distances[i*width+j] = len(b) - j
+			case j == len(b):
+				distances[i*width+j] = len(a) - i
+			case equalValues(a[i], b[j]):
+				distances[i*width+j] = distances[(i+1)*width+j+1]
+			default:
+				d := distances[(i+1)*width+j+1]
+				if distances[(i+1)*width+j] < d {
+					d = distances[(i+1)*width+j]
+				}
+				if distances[i*width+j+1] < d {
+					d = distances[i*width+j+1]
+				}
+				distances[i*width+j] = d + 1
+			}
+		}
+	}
+	// the operations are in the order of the elements, where index is the one in the array being patched,
+	// before which the elements are already the same as the ones in b
+	index, removed := start, 0
+	for i, j := 0, 0; i < len(a) || j < len(b); {
+		d := distances[i*width+j]
+		switch {
+		case i < len(a) && j < len(b) && equalValues(a[i], b[j]):
+			operations = removeOperations(pointer, index, removed, operations)
+			index, removed = index+1, 0
+			i++
+			j++
+		case i < len(a) && j < len(b) && d == distances[(i+1)*width+j+1]+1:
+			operations = removeOperations(pointer, index, removed, operations)
+			operations = patchOperations(pointer+slash+strconv.Itoa(index), a[i], b[j], operations)
+			index, removed = index+1, 0
+			i++
+			j++
+		case i < len(a) && d == distances[(i+1)*width+j]+1:
+			removed++
+			i++
+		default:
+			operations = removeOperations(pointer, index, removed, operations)
+			operations = append(operations, patchOperation(opAdd, pointer+slash+strconv.Itoa(index), b[j]))
+			index, removed = index+1, 0
+			j++
+		}
+	}
+	return removeOperations(pointer, index, removed, operations)
+}
+
+// indexPatchOperations adds the operations comparing the elements of the arrays index by index,
+// where the elements of a start at the index provided in the array being patched.
+func indexPatchOperations(pointer string, start int, a, b []interface{}, operations []interface{}) []interface{} {
+	i := 0
+	for ; i < len(a) && i < len(b); i++ {
+		operations = patchOperations(pointer+slash+strconv.Itoa(start+i), a[i], b[i], operations)
+	}
+	// the ones from the end, so that the indices of the others are not shifted
+	operations = removeOperations(pointer, start+i, len(a)-i, operations)
+	for ; i < len(b); i++ {
+		operations = append(operations, patchOperation(opAdd, pointer+slash+strconv.Itoa(start+i), b[i]))
+	}
+	return operations
+}
+
+// removeOperations adds the operations removing the elements starting at the index,
+// from the last one, so that the indices of the others are not shifted.
+func removeOperations(pointer string, index, count int, operations []interface{}) []interface{} {
+	for k := index + count - 1; k >= index; k-- {
+		operations = append(operations, patchOperation(opRemove, pointer+slash+strconv.Itoa(k), nil))
+	}
+	return operations
+}
+
+func patchOperation(op, path string, value interface{}) map[string]interface{} {
+	operation := map[string]interface{}{"op": op, "path": path}
+	if op != opRemove {
+		operation["value"] = value
+	}
+	return operation
+}
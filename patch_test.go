@@ -0,0 +1,216 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+var patchErrors = map[string]error{
+	"test":    jsonic.ErrTestFailed,
+	"missing": jsonic.ErrNoDataFound,
+	"bounds":  jsonic.ErrIndexOutOfBound,
+	"root":    jsonic.ErrDeleteRoot,
+	"invalid": jsonic.ErrInvalidPatch,
+}
+
+func mustBytes(t *testing.T, j *jsonic.Jsonic, path string) []byte {
+	b, err := mustChild(t, j, path).Bytes()
+	assert.NoError(t, err)
+	return b
+}
+
+func TestApplyPatch(t *testing.T) {
+	cases, err := jsonic.New(readFromFile("test_data/test16.json", t))
+	assert.NoError(t, err)
+	all, err := cases.Query("[*]")
+	assert.NoError(t, err)
+	for _, c := range all {
+		comment, err := c.GetString("comment")
+		assert.NoError(t, err)
+		doc := mustBytes(t, c, "doc")
+		j, err := jsonic.New(doc)
+		assert.NoError(t, err)
+
+		patched, err := j.ApplyPatch(mustBytes(t, c, "patch"))
+		if reason, failure := c.GetString("error"); failure == nil {
+			assert.Nil(t, patched, comment)
+			var patchErr *jsonic.PatchError
+			assert.True(t, errors.As(err, &patchErr), comment)
+			assert.True(t, errors.Is(err, patchErrors[reason]), comment+": "+err.Error())
+		} else {
+			assert.NoError(t, err, comment)
+			b, err := patched.Bytes()
+			assert.NoError(t, err)
+			assert.Equal(t, string(mustBytes(t, c, "expected")), string(b), comment)
+		}
+		// the json tree patched is not modified
+		b, err := j.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, string(doc), string(b), comment)
+	}
+}
+
+func TestApplyPatchError(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": {"b": [1, 2]}}`))
+	assert.NoError(t, err)
+
+	_, err = j.ApplyPatch([]byte(`{"op": "add"}`))
+	assert.Equal(t, jsonic.ErrInvalidPatch, err)
+	_, err = j.ApplyPatch([]byte(`[`))
+	assert.Error(t, err)
+
+	_, err = j.ApplyPatch([]byte(`[{"op": "test", "path": "/a/b/0", "value": 1}, {"op": "remove", "path": "/a/c"}]`))
+	var patchErr *jsonic.PatchError
+	assert.True(t, errors.As(err, &patchErr))
+	assert.Equal(t, 1, patchErr.Operation)
+	assert.Equal(t, "remove", patchErr.Op)
+	assert.Equal(t, "/a/c", patchErr.Path)
+	var pathErr *jsonic.PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, 1, pathErr.Segment)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Equal(t, `jsonic: patch operation 1 "remove" at "/a/c": `+pathErr.Error(), err.Error())
+
+	_, err = j.ApplyPatch([]byte(`[{"op": "add", "path": "a", "value": 1}]`))
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+
+	for _, patch := range []string{`[1]`, `[{"path": "/a"}]`, `[{"op": "add", "value": 1}]`, `[{"op": "copy", "path": "/c"}]`,
+		`[{"op": "move", "from": 1, "path": "/c"}]`} {
+		_, err = j.ApplyPatch([]byte(patch))
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidPatch), patch)
+	}
+}
+
+func TestApplyPatchCache(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": {"b": [1, 2]}}`))
+	assert.NoError(t, err)
+	patched, err := j.ApplyPatch([]byte(`[{"op": "add", "path": "/a/c", "value": 3}]`))
+	assert.NoError(t, err)
+	// the children resolved before further patching are refreshed
+	b := mustChild(t, patched, "a.b")
+	patched, err = patched.ApplyPatch([]byte(`[{"op": "remove", "path": "/a/b/0"}, {"op": "replace", "path": "/a/c", "value": 4}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1.0, 2.0}, mustGet(t, b, "."))
+	assert.Equal(t, []interface{}{2.0}, mustGet(t, patched, "a.b"))
+	assert.Equal(t, 4.0, mustGet(t, patched, "a.c"))
+}
+
+func mustGet(t *testing.T, j *jsonic.Jsonic, path string) interface{} {
+	data, err := j.Get(path)
+	assert.NoError(t, err)
+	return data
+}
+
+func TestCreatePatch(t *testing.T) {
+	cases := []struct {
+		a, b, patch string
+	}{
+		{`{"a": 1}`, `{"a": 1}`, `[]`},
+		{`{"a": 1, "b": 2}`, `{"a": 1.0, "c": 2}`, `[{"op":"remove","path":"/b"},{"op":"add","path":"/c","value":2}]`},
+		{`{"a": {"x": [1, 2, 3]}}`, `{"a": {"x": [1, 5, 2, 3]}}`, `[{"op":"add","path":"/a/x/1","value":5}]`},
+		{`[1, 2, 3, 4]`, `[1, 4]`, `[{"op":"remove","path":"/2"},{"op":"remove","path":"/1"}]`},
+		{`[1, {"a": 1}, 3]`, `[1, {"a": 2}, 3]`, `[{"op":"replace","path":"/1/a","value":2}]`},
+		{`{"a/b": {"c~d": 1}}`, `{"a/b": {"c~d": [1]}}`, `[{"op":"replace","path":"/a~1b/c~0d","value":[1]}]`},
+		{`{"a": 1}`, `[1]`, `[{"op":"replace","path":"","value":[1]}]`},
+		{`[1, 2]`, `[3, 4, 5]`, `[{"op":"replace","path":"/0","value":3},{"op":"replace","path":"/1","value":4},` +
+			`{"op":"add","path":"/2","value":5}]`},
+	}
+	for _, c := range cases {
+		a, err := jsonic.New([]byte(c.a))
+		assert.NoError(t, err)
+		b, err := jsonic.New([]byte(c.b))
+		assert.NoError(t, err)
+		patch, err := jsonic.CreatePatch(a, b)
+		assert.NoError(t, err)
+		assert.Equal(t, c.patch, string(patch), c.a+" "+c.b)
+
+		patched, err := a.ApplyPatch(patch)
+		assert.NoError(t, err)
+		expected, _ := b.Get(".")
+		actual, _ := patched.Get(".")
+		assert.Equal(t, expected, actual, c.a+" "+c.b)
+	}
+}
+
+func TestCreatePatchArrays(t *testing.T) {
+	cases := []struct {
+		a, b       string
+		operations int
+	}{
+		{`[1, 2, 3]`, `[2, 3, 4]`, 2},
+		{`[1, 2, 3, 4, 5, 6]`, `[2, 3, 4, 5, 6, 7]`, 2},
+		{`[1, 2, 3, 4, 5]`, `[5, 1, 2, 3, 4]`, 2},
+		{`["x", 1, 2, 3]`, `[1, 2, 3, "y"]`, 2},
+		{`[1, 2, 3, 4]`, `[1, 3, 2, 4]`, 2},
+		{`[1, 2, 3]`, `[4, 2, 5]`, 2},
+		{`[1, 2, 3, 4]`, `[5, 2, 6, 4, 7]`, 3},
+		{`[]`, `[1, 2]`, 2},
+		{`[1, 2]`, `[]`, 2},
+		{`[[1, 2], 3]`, `[3, [1, 2]]`, 2},
+		{`[{"a": 1}, 2, 3]`, `[2, 3, {"a": 2}]`, 2},
+	}
+	for _, c := range cases {
+		a, err := jsonic.New([]byte(c.a))
+		assert.NoError(t, err)
+		b, err := jsonic.New([]byte(c.b))
+		assert.NoError(t, err)
+		patch, err := jsonic.CreatePatch(a, b)
+		assert.NoError(t, err)
+		operations, err := jsonic.New(patch)
+		assert.NoError(t, err)
+		array, err := operations.GetArray(".")
+		assert.NoError(t, err)
+		assert.Len(t, array, c.operations, string(patch))
+
+		patched, err := a.ApplyPatch(patch)
+		assert.NoError(t, err)
+		expected, _ := b.Get(".")
+		actual, _ := patched.Get(".")
+		assert.Equal(t, expected, actual, c.a+" "+c.b)
+	}
+}
+
+func TestCreatePatchLargeArrays(t *testing.T) {
+	x, y := make([]interface{}, 2000), make([]interface{}, 2000)
+	for i := range x {
+		x[i], y[i] = float64(i), float64(i+1)
+	}
+	a, err := jsonic.New([]byte(`{}`))
+	assert.NoError(t, err)
+	assert.NoError(t, a.Set("x", x))
+	b, err := jsonic.New([]byte(`{}`))
+	assert.NoError(t, err)
+	assert.NoError(t, b.Set("x", y))
+
+	// too large to find the fewest operations, so the elements are compared index by index
+	patch, err := jsonic.CreatePatch(a, b)
+	assert.NoError(t, err)
+	patched, err := a.ApplyPatch(patch)
+	assert.NoError(t, err)
+	expected, _ := b.Get("x")
+	actual, _ := patched.Get("x")
+	assert.Equal(t, expected, actual)
+}
+
+func TestCreatePatchLarge(t *testing.T) {
+	a, err := jsonic.NewWithOptions(readFromFile("test_data/test9.json", t), jsonic.Lazy())
+	assert.NoError(t, err)
+	b, err := jsonic.New(readFromFile("test_data/test9.json", t))
+	assert.NoError(t, err)
+	assert.NoError(t, b.Set("items[1].tags[1]", "leaf"))
+	assert.NoError(t, b.Delete("items[2]"))
+	assert.NoError(t, b.Set("ranks.z", map[string]interface{}{"level": 9}))
+
+	patch, err := jsonic.CreatePatch(a, b)
+	assert.NoError(t, err)
+	patched, err := a.ApplyPatch(patch)
+	assert.NoError(t, err)
+	expected, err := b.Bytes()
+	assert.NoError(t, err)
+	actual, err := patched.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -0,0 +1,314 @@
+package jsonic
+
+import (
+	"strconv"
+	"strings"
+)
+
+type pathParser struct {
+	path string
+	pos  int
+}
+
+// parsePath splits the path into its elements.
+//
+// The path is split on the dots outside the square brackets, and then
+// the indices enclosed within square brackets are separated from the end
+// of each part.
+// So a.b[0][1] has the elements a, b, [0] and [1], of which only b is
+// separated with a dot from the previous one. Anything not looking
+// like an index is kept as a part of the key.
+//
+// A key can also be written exactly, either by escaping the special
+// characters with a backslash, like a\.b, or by quoting it within the
+// square brackets, like ["a.b"]. Such a key is never joined with the
+// other elements while resolving the path.
+func parsePath(path string) ([]pathElement, error) {
+	p := &pathParser{path: path}
+	elements := make([]pathElement, 0, strings.Count(path, dot)+strings.Count(path, "[")+1)
+	separator := empty
+	if strings.HasPrefix(path, descent) {
+		elements = append(elements, pathElement{nature: natureDescent, key: descent})
+		p.pos = len(descent)
+		if p.pos == len(p.path) {
+			return elements, nil
+		}
+	}
+	for {
+		var err error
+		elements, err = p.parseSegment(elements, separator)
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.path) {
+			return elements, nil
+		}
+		// skip the dot
+		p.pos++
+		separator = dot
+		if p.pos < len(p.path) && p.path[p.pos] == '.' {
+			// two consecutive dots mean the recursive descent
+			elements = append(elements, pathElement{nature: natureDescent, key: descent, separator: dot})
+			p.pos++
+			if p.pos == len(p.path) {
+				return elements, nil
+			}
+		}
+	}
+}
+
+// parseSegment parses the elements till the next dot, adding them to the elements provided,
+// with the first of them separated from the previous ones by the separator.
+//
+// The key is sliced from the path, and it is copied only when it has the escaped characters.
+func (p *pathParser) parseSegment(elements []pathElement, separator string) ([]pathElement, error) {
+	start, end, first := p.pos, p.pos, len(elements)
+	var key strings.Builder
+	exact := false
+	for p.pos < len(p.path) {
+		c := p.path[p.pos]
+		if c == '.' {
+			break
+		}
+		if c == '[' {
+			element, ok, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				elements = append(elements, element)
+				continue
+			}
+		}
+		// this is a part of the key, so the brackets before it are as well
+		for _, b := range elements[first:] {
+			if b.exact {
+				// a quoted key should be followed by a dot or another bracket
+				return nil, ErrInvalidPath
+			}
+			if exact {
+				key.WriteString(b.key)
+			}
+		}
+		elements = elements[:first]
+		if c == '\\' {
+			if p.pos+1 == len(p.path) {
+				// nothing to escape
+				return nil, ErrInvalidPath
+			}
+			if !exact {
+				key.WriteString(p.path[start:p.pos])
+				exact = true
+			}
+			p.pos++
+			c = p.path[p.pos]
+		}
+		if exact {
+			key.WriteByte(c)
+		}
+		p.pos++
+		end = p.pos
+	}
+	if end > start || len(elements) == first {
+		element := pathElement{nature: natureKey, key: p.path[start:end]}
+		if exact {
+			element.key, element.exact = key.String(), true
+		} else if element.key == wildcard {
+			element.nature = natureWildcard
+		}
+		// the key comes before the brackets following it
+		elements = append(elements, pathElement{})
+		copy(elements[first+1:], elements[first:])
+		elements[first] = element
+	}
+	elements[first].separator = separator
+	return elements, nil
+}
+
+// parseBracket parses the element within the square brackets at the current position.
+// It reports whether there is an element, or the bracket is just a part of the key.
+func (p *pathParser) parseBracket() (pathElement, bool, error) {
+	start := p.pos
+	if start+1 < len(p.path) && (p.path[start+1] == '"' || p.path[start+1] == '\'') {
+		key, err := p.parseQuoted(start + 1)
+		if err != nil {
+			return pathElement{}, false, err
+		}
+		return pathElement{nature: natureKey, key: key, exact: true}, true, nil
+	}
+	if start+1 < len(p.path) && p.path[start+1] == '?' {
+		filter, end, err := parseFilter(p.path, start)
+		if err != nil {
+			return pathElement{}, false, err
+		}
+		p.pos = end
+		return pathElement{nature: natureFilter, key: p.path[start:end], filter: filter}, true, nil
+	}
+	end := strings.IndexByte(p.path[start:], ']')
+	if end < 0 {
+		return pathElement{}, false, nil
+	}
+	end += start + 1
+	raw := p.path[start:end]
+	content := raw[1 : len(raw)-1]
+	if content == wildcard {
+		p.pos = end
+		return pathElement{nature: natureWildcard, key: raw}, true, nil
+	}
+	if strings.Contains(content, ":") {
+		s, ok := parseSlice(content)
+		if !ok {
+			return pathElement{}, false, nil
+		}
+		p.pos = end
+		return pathElement{nature: natureSlice, key: raw, slice: s}, true, nil
+	}
+	index, err := getIndex(raw)
+	if err != nil {
+		return pathElement{}, false, nil
+	}
+	p.pos = end
+	return pathElement{nature: natureIndex, key: raw, index: index}, true, nil
+}
+
+// parseSlice parses the slice in the form start:end:step, where each of them is optional.
+func parseSlice(content string) (*slice, bool) {
+	parts := strings.Split(content, ":")
+	if len(parts) > 3 {
+		return nil, false
+	}
+	s := &slice{step: 1}
+	for i, part := range parts {
+		if part == empty {
+			continue
+		}
+		n, err := parseInt(part)
+		if err != nil {
+			return nil, false
+		}
+		switch i {
+		case 0:
+			s.start = &n
+		case 1:
+			s.end = &n
+		case 2:
+			s.step = n
+		}
+	}
+	return s, true
+}
+
+// parseInt parses the integer in the path, which can have a minus sign, but not a plus sign.
+func parseInt(s string) (int, error) {
+	if strings.HasPrefix(s, "+") {
+		return 0, strconv.ErrSyntax
+	}
+	return strconv.Atoi(s)
+}
+
+// parseQuoted parses the quoted key starting at the position provided,
+// followed by the closing square bracket.
+func (p *pathParser) parseQuoted(start int) (string, error) {
+	quote := p.path[start]
+	// the key is sliced from the path, and it is copied only when it has the escaped characters
+	var key strings.Builder
+	escaped := false
+	for i := start + 1; i < len(p.path); i++ {
+		c := p.path[i]
+		if c == '\\' {
+			if !escaped {
+				key.WriteString(p.path[start+1 : i])
+				escaped = true
+			}
+			i++
+			if i == len(p.path) {
+				break
+			}
+			key.WriteByte(p.path[i])
+			continue
+		}
+		if c == quote {
+			if i+1 == len(p.path) || p.path[i+1] != ']' {
+				return empty, ErrInvalidPath
+			}
+			p.pos = i + 2
+			if !escaped {
+				return p.path[start+1 : i], nil
+			}
+			return key.String(), nil
+		}
+		if escaped {
+			key.WriteByte(c)
+		}
+	}
+	// not terminated
+	return empty, ErrInvalidPath
+}
+
+// joinable reports whether the element can be joined with the others to form a key.
+func (e pathElement) joinable() bool {
+	return !e.exact && (e.nature == natureKey || e.nature == natureIndex)
+}
+
+// singular reports whether the element can select at most a single json tree.
+func (e pathElement) singular() bool {
+	return e.nature == natureKey || e.nature == natureIndex || e.nature == natureToken
+}
+
+// indexIn returns the index in the array of the length provided.
+func (e pathElement) indexIn(length int) (int, error) {
+	index, err := e.arrayIndex()
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 {
+		// counted from the end of the array
+		index += length
+	}
+	if index < 0 || index >= length {
+		return 0, ErrIndexOutOfBound
+	}
+	return index, nil
+}
+
+// arrayIndex returns the index referred by the element, which is negative when counted from the end.
+func (e pathElement) arrayIndex() (int, error) {
+	switch {
+	case e.nature == natureToken:
+		// a reference token of the json pointer is never counted from the end
+		return tokenIndex(e.key)
+	case e.nature != natureIndex:
+		if e.exact {
+			return 0, ErrIndexNotFound
+		}
+		// a plain key is also accepted as an index
+		i, err := getIndex(e.key)
+		if err != nil {
+			return 0, ErrIndexNotFound
+		}
+		return i, nil
+	}
+	return e.index, nil
+}
+
+// expects returns the kinds of the json data the element can be resolved on.
+func (e pathElement) expects() Kind {
+	switch e.nature {
+	case natureIndex, natureSlice:
+		return KindArray
+	case natureWildcard, natureFilter:
+		return KindArray | KindObject
+	case natureDescent:
+		return KindNull | KindBool | KindNumber | KindString | KindArray | KindObject
+	case natureToken:
+		if _, err := tokenIndex(e.key); err == nil {
+			return KindArray | KindObject
+		}
+	case natureKey:
+		if _, err := getIndex(e.key); err == nil && !e.exact {
+			// a plain key is also accepted as an index
+			return KindArray | KindObject
+		}
+	}
+	return KindObject
+}
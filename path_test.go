@@ -0,0 +1,284 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChildIndices(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	s, err := j.GetString("a.arr[0].a")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", s)
+
+	s, err = j.GetString("a.arr[0].c.d.e")
+	assert.NoError(t, err)
+	assert.Equal(t, "f", s)
+
+	s, err = j.GetString("a.arr[-1].a")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", s)
+
+	c, err := j.Child("a.arr[-2]")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Nil(t, c)
+}
+
+func TestChildSignedIndices(t *testing.T) {
+	j, err := jsonic.New([]byte(`{"a": [1, 2, 3], "b": {"[+1]": "key"}}`))
+	assert.NoError(t, err)
+
+	// only the minus sign is allowed, so the others are not the indices
+	for _, path := range []string{"a[+1]", "a.[+1]", "a[+0:+2]", "a[0:2:+1]", "a[*][+0]"} {
+		_, err = j.Get(path)
+		assert.True(t, errors.Is(err, jsonic.ErrNoDataFound), path)
+		results, err := j.Query(path)
+		assert.NoError(t, err, path)
+		assert.Empty(t, results, path)
+	}
+	_, err = jsonic.GetBytes([]byte(`{"a": [1, 2, 3]}`), "a[+1]")
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	_, err = jsonic.Compile(`["a"][+1]`)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+	s, err := j.GetString("b.[+1]")
+	assert.NoError(t, err)
+	assert.Equal(t, "key", s)
+
+	i, err := j.GetInt("a[-1]")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, i)
+}
+
+func TestChildNestedIndices(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test5.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i, err := j.GetInt("a[0][1]")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, i)
+
+	i, err = j.GetInt("a[1][1][0]")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, i)
+
+	i, err = j.GetInt("a[-1][0]")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, i)
+
+	// backward compatible forms
+	i, err = j.GetInt("a.[1].[1].[-1]")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, i)
+
+	i, err = j.GetInt("a.1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, i)
+
+	// index on the root array
+	r, err := jsonic.New([]byte(`[[1, 2], [3]]`))
+	assert.NoError(t, err)
+	i, err = r.GetInt("[1][0]")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, i)
+
+	i, err = r.GetInt("[2][0]")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrIndexOutOfBound))
+	assert.Equal(t, 0, i)
+
+	i, err = r.GetInt("[0].a")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrIndexNotFound))
+	assert.Equal(t, 0, i)
+}
+
+func TestChildIndicesPreference(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test5.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	// the index is preferred over the key containing it
+	s, err := j.GetString("b.c[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, "x", s)
+
+	s, err = j.GetString("b.c[1]")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Equal(t, "", s)
+
+	err = j.Delete("b.c")
+	assert.NoError(t, err)
+	s, err = j.GetString("b.c[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, "literal", s)
+
+	// not an index, so part of the key
+	s, err = j.GetString("d.e[x]")
+	assert.NoError(t, err)
+	assert.Equal(t, "y", s)
+}
+
+func TestSetIndices(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test5.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	err = j.Set("a[1][1][-1]", 6)
+	assert.NoError(t, err)
+	i, err := j.GetInt("a[1][1][1]")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, i)
+
+	// the missing array is created
+	err = j.Set("m.list[0].n", "naruto")
+	assert.NoError(t, err)
+	s, err := j.GetString("m.list[0].n")
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", s)
+	a, err := j.GetArray("m.list")
+	assert.NoError(t, err)
+	assert.Len(t, a, 1)
+}
+
+func TestChildExactKeys(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	s, err := j.GetString(`a.arr[0]["c.d"].e`)
+	assert.NoError(t, err)
+	assert.Equal(t, "f", s)
+
+	s, err = j.GetString(`a.arr[0].c\.d.e`)
+	assert.NoError(t, err)
+	assert.Equal(t, "f", s)
+
+	// the greedy search prefers a, but the exact key is a.x
+	s, err = j.GetString(`a.x`)
+	assert.NoError(t, err)
+	assert.Equal(t, "p", s)
+
+	s, err = j.GetString(`a\.x.y`)
+	assert.NoError(t, err)
+	assert.Equal(t, "q", s)
+
+	s, err = j.GetString(`['a.x'].y`)
+	assert.NoError(t, err)
+	assert.Equal(t, "q", s)
+
+	s, err = j.GetString(`a\.x\.y.z`)
+	assert.NoError(t, err)
+	assert.Equal(t, "r", s)
+
+	s, err = j.GetString(`["a"].x`)
+	assert.NoError(t, err)
+	assert.Equal(t, "p", s)
+
+	// the exact key is not joined with the following elements
+	s, err = j.GetString(`["a"].x.y`)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Equal(t, "", s)
+}
+
+func TestChildExactSpecialKeys(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test6.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	i, err := j.GetInt(`o["[0]"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	i, err = j.GetInt(`o.\[0\]`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	i, err = j.GetInt(`o.[0]`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	i, err = j.GetInt(`o.0`)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, i)
+
+	i, err = j.GetInt(`o.a.b.c`)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, i)
+
+	i, err = j.GetInt(`o.a\.b.c`)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, i)
+
+	i, err = j.GetInt(`o["q\"'"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, i)
+
+	i, err = j.GetInt(`o['q"\'']`)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, i)
+
+	i, err = j.GetInt(`o.back\\slash`)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, i)
+
+	// exact keys are never indices
+	i, err = j.GetInt(`arr["0"]`)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Equal(t, 0, i)
+
+	r, err := jsonic.New([]byte(`[1]`))
+	assert.NoError(t, err)
+	i, err = r.GetInt(`\0`)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrIndexNotFound))
+	assert.Equal(t, 0, i)
+}
+
+func TestChildInvalidPath(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test6.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	for _, path := range []string{`o["a`, `o["a"]b`, `o["a"`, `o\`, `o['a"]`} {
+		c, err := j.Child(path)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+		assert.Nil(t, c)
+	}
+
+	err = j.Set(`o["a`, 1)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+}
+
+func TestSetExactKeys(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test6.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	err = j.Set(`o.a\.b.c`, 7)
+	assert.NoError(t, err)
+	i, err := j.GetInt(`o["a.b"].c`)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, i)
+	i, err = j.GetInt(`o.a.b.c`)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, i)
+
+	err = j.Set(`m\.n`, 8)
+	assert.NoError(t, err)
+	m, err := j.GetMap(".")
+	assert.NoError(t, err)
+	assert.Equal(t, 8.0, m["m.n"])
+}
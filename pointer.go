@@ -0,0 +1,118 @@
+package jsonic
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ChildPointer returns the json tree at the json pointer specified, as per RFC 6901.
+//
+// The pointer is either empty, referring to the whole json tree, or a sequence
+// of reference tokens each prefixed by a slash, like /a/arr/0/c.d/e. Within a
+// token, ~1 refers to a slash and ~0 refers to a tilde. As opposed to the
+// path, each token is exactly the key of an object, or the index of an array.
+func (j *Jsonic) ChildPointer(pointer string) (*Jsonic, error) {
+	if pointer == empty {
+		return j, nil
+	}
+	p := &Path{path: pointer}
+	p.elements, p.err = parsePointer(pointer)
+	if p.err != nil {
+		p.err = p.errorAt(-1, 0, 0, p.err)
+	}
+	return p.Child(j)
+}
+
+// GetPointer is used to get the data at the json pointer specified.
+func (j *Jsonic) GetPointer(pointer string) (interface{}, error) {
+	child, err := j.ChildPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return child.value(), nil
+}
+
+// Pointer returns the json pointer of this json tree, relative to the root
+// it was resolved from. So, the pointer can be used with the root to get
+// the same json tree back. The pointer of the root itself is empty.
+func (j *Jsonic) Pointer() string {
+	var tokens []string
+	for c := j; c.parent != nil; c = c.parent {
+		tokens = append(tokens, escapeToken(c.key))
+	}
+	var b strings.Builder
+	for i := len(tokens) - 1; i >= 0; i-- {
+		b.WriteString(slash)
+		b.WriteString(tokens[i])
+	}
+	return b.String()
+}
+
+// parsePathOrPointer parses the json pointer in case it begins with a slash,
+// otherwise the path.
+func parsePathOrPointer(path string) ([]pathElement, error) {
+	if strings.HasPrefix(path, slash) {
+		return parsePointer(path)
+	}
+	return parsePath(path)
+}
+
+// parsePointer splits the json pointer into its reference tokens.
+func parsePointer(pointer string) ([]pathElement, error) {
+	if !strings.HasPrefix(pointer, slash) {
+		return nil, ErrInvalidPath
+	}
+	tokens := strings.Split(pointer[1:], slash)
+	elements := make([]pathElement, 0, len(tokens))
+	for _, token := range tokens {
+		key, err := unescapeToken(token)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, pathElement{nature: natureToken, key: key, exact: true})
+	}
+	return elements, nil
+}
+
+func unescapeToken(token string) (string, error) {
+	if !strings.Contains(token, "~") {
+		return token, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(token); i++ {
+		if token[i] != '~' {
+			b.WriteByte(token[i])
+			continue
+		}
+		i++
+		if i == len(token) {
+			return empty, ErrInvalidPath
+		}
+		switch token[i] {
+		case '0':
+			b.WriteByte('~')
+		case '1':
+			b.WriteByte('/')
+		default:
+			return empty, ErrInvalidPath
+		}
+	}
+	return b.String(), nil
+}
+
+func escapeToken(key string) string {
+	return strings.Replace(strings.Replace(key, "~", "~0", -1), slash, "~1", -1)
+}
+
+// tokenIndex returns the array index referred to by the reference token,
+// which should not have a sign or leading zeros.
+func tokenIndex(token string) (int, error) {
+	if token == empty || (len(token) > 1 && token[0] == '0') || token[0] < '0' || token[0] > '9' {
+		return 0, ErrIndexNotFound
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, ErrIndexNotFound
+	}
+	return index, nil
+}
@@ -0,0 +1,144 @@
+package jsonic_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChildPointer(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	c, err := j.ChildPointer("/a/arr/0/c.d/e")
+	assert.NoError(t, err)
+	s, err := c.GetString(".")
+	assert.NoError(t, err)
+	assert.Equal(t, "f", s)
+
+	// same as the path
+	d, err := j.Child("a.arr.[0].c\\.d.e")
+	assert.NoError(t, err)
+	assert.Equal(t, c, d)
+
+	// keys containing the dots are never split
+	v, err := j.GetPointer("/a.x/y")
+	assert.NoError(t, err)
+	assert.Equal(t, "q", v)
+	_, err = j.GetPointer("/a/x/y")
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+
+	// whole document
+	c, err = j.ChildPointer("")
+	assert.NoError(t, err)
+	assert.Equal(t, j, c)
+}
+
+func TestChildPointerEscapes(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test8.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	v, err := j.GetPointer("/a~1b/m~0n")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, v)
+	// ~01 is the tilde followed by 1, and not the slash
+	v, err = j.GetPointer("/a~1b/~01")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, v)
+	v, err = j.GetPointer("//")
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, v)
+	v, err = j.GetPointer("/~1x")
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, v)
+
+	for _, pointer := range []string{"a", "/a~", "/a~2b", "/~"} {
+		_, err = j.GetPointer(pointer)
+		assert.True(t, errors.Is(err, jsonic.ErrInvalidPath), pointer)
+	}
+}
+
+func TestChildPointerIndices(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test8.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	v, err := j.GetPointer("/arr/1")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, v)
+	v, err = j.GetPointer("/arr/2/k")
+	assert.NoError(t, err)
+	assert.Equal(t, "v", v)
+
+	c, err := j.ChildPointer("/arr")
+	assert.NoError(t, err)
+	_, err = c.GetPointer("/3")
+	assert.True(t, errors.Is(err, jsonic.ErrIndexOutOfBound))
+	for _, pointer := range []string{"/-1", "/01", "/-", "/+1", "/", "/[0]"} {
+		_, err = c.GetPointer(pointer)
+		assert.True(t, errors.Is(err, jsonic.ErrIndexNotFound), pointer)
+	}
+}
+
+func TestChildWithPointer(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test8.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	s, err := j.GetString("/arr/2/k")
+	assert.NoError(t, err)
+	assert.Equal(t, "v", s)
+	i, err := j.GetInt("/a~1b/m~0n")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	// escaped slash for the path
+	i, err = j.GetInt("\\/x")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, i)
+
+	// modification
+	err = j.Set("/arr/2/k", "w")
+	assert.NoError(t, err)
+	s, err = j.GetString("arr[2].k")
+	assert.NoError(t, err)
+	assert.Equal(t, "w", s)
+	err = j.Delete("/a~1b/m~0n")
+	assert.NoError(t, err)
+	_, err = j.Get("/a~1b/m~0n")
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+}
+
+func TestPointer(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test8.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	assert.Equal(t, "", j.Pointer())
+	for path, pointer := range map[string]string{
+		"arr[2].k":       "/arr/2/k",
+		"arr[-1]":        "/arr/2",
+		`["a/b"]["m~n"]`: "/a~1b/m~0n",
+		`["a/b"]["~1"]`:  "/a~1b/~01",
+		`[""][""]`:       "//",
+	} {
+		c, err := j.Child(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, pointer, c.Pointer(), path)
+		// resolving the pointer gives the same json tree back
+		d, err := j.ChildPointer(pointer)
+		assert.NoError(t, err, pointer)
+		assert.Equal(t, c, d, pointer)
+	}
+
+	// relative to the root, even when resolved from a child
+	c, err := j.Child("arr")
+	assert.NoError(t, err)
+	d, err := c.Child("[2].k")
+	assert.NoError(t, err)
+	assert.Equal(t, "/arr/2/k", d.Pointer())
+}
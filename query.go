@@ -0,0 +1,174 @@
+package jsonic
+
+import (
+	"sort"
+	"strconv"
+)
+
+// slice selects the elements of an array from start till end, exclusive, in the steps provided.
+type slice struct {
+	start *int
+	end   *int
+	step  int
+}
+
+// Query returns all the json trees at the path specified.
+//
+// Apart from everything supported by Child, the path can contain
+// the following elements selecting multiple json trees.
+//
+// * or [*] selects all the elements of an array, or all the values of an object.
+//
+// [start:end:step] selects the elements of an array like a python slice,
+// where each of start, end and step is optional, like [1:5], [::2], [-2:].
+//
+// .. selects the json tree along with all its descendants, so ..id selects
+// the value of id in every object of the json tree.
+//
+// [?(predicate)] selects the elements of an array, or the values of an object,
+// satisfying the predicate, like [?(@.price > 10 && @.tags[0] == 'new')].
+// Here @ refers to each of the elements, and it is followed by a path resolved
+// in the same way as it is done in Child. The predicate can contain the
+// comparisons with ==, !=, <, <=, >, >=, the logical operators &&, || and !,
+// the existence checks like @.isbn, the regular expression matches like
+// @.name =~ /^na/, and the functions length, contains and starts_with.
+//
+// The results are in the order they appear in the json data, with the keys
+// of the objects taken in the sorted order. It returns an empty result in
+// case nothing can be resolved at the specified path.
+func (j *Jsonic) Query(path string) ([]*Jsonic, error) {
+	if path == dot || path == empty {
+		return []*Jsonic{j.getDotOrEmptyChild(path)}, nil
+	}
+	p, err := Compile(path)
+	if err != nil {
+		return nil, err
+	}
+	results, _ := j.children(p.elements, -1, make([]*Jsonic, 0))
+	return results, nil
+}
+
+func (j *Jsonic) childrenFromSelected(path []pathElement, limit int, results []*Jsonic) ([]*Jsonic, error) {
+	count := len(results)
+	for _, child := range j.selected(path[0]) {
+		if reached(limit, results) {
+			break
+		}
+		results, _ = child.children(path[1:], limit, results)
+	}
+	if len(results) == count {
+		return results, ErrNoDataFound
+	}
+	return results, nil
+}
+
+func (j *Jsonic) childrenFromDescendants(path []pathElement, limit int, results []*Jsonic) ([]*Jsonic, error) {
+	count := len(results)
+	results = j.descendants(path, limit, results)
+	if len(results) == count {
+		return results, ErrNoDataFound
+	}
+	return results, nil
+}
+
+// descendants adds the json trees at the path, from this tree as well as from all its descendants.
+func (j *Jsonic) descendants(path []pathElement, limit int, results []*Jsonic) []*Jsonic {
+	results, _ = j.children(path, limit, results)
+	for _, child := range j.selected(pathElement{nature: natureWildcard}) {
+		if reached(limit, results) {
+			break
+		}
+		results = child.descendants(path, limit, results)
+	}
+	return results
+}
+
+// selected returns the children selected by the wildcard, the slice or the filter element.
+func (j *Jsonic) selected(element pathElement) []*Jsonic {
+	if element.nature == natureFilter {
+		return j.selectedByFilter(element.filter)
+	}
+	switch data := j.shallow().(type) {
+	case []interface{}:
+		indices := element.slice.indices(len(data))
+		selected := make([]*Jsonic, 0, len(indices))
+		for _, index := range indices {
+			selected = append(selected, j.childAt(strconv.Itoa(index), data[index]))
+		}
+		return selected
+	case map[string]interface{}:
+		if element.nature != natureWildcard {
+			// slice is only for the arrays
+			return nil
+		}
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		selected := make([]*Jsonic, 0, len(keys))
+		for _, k := range keys {
+			selected = append(selected, j.childAt(k, data[k]))
+		}
+		return selected
+	}
+	return nil
+}
+
+// indices returns the indices selected in the array of the length provided.
+// A nil slice selects all of them.
+func (s *slice) indices(length int) []int {
+	if s == nil {
+		s = &slice{step: 1}
+	}
+	var indices []int
+	if s.step > 0 {
+		lower := s.bound(s.start, 0, length)
+		upper := s.bound(s.end, length, length)
+		for i := lower; i < upper; i += s.step {
+			indices = append(indices, i)
+			if s.step > upper-i {
+				// stepping further would overflow
+				break
+			}
+		}
+	} else if s.step < 0 {
+		upper := s.bound(s.start, length-1, length)
+		lower := s.bound(s.end, -1, length)
+		for i := upper; i > lower; i += s.step {
+			indices = append(indices, i)
+			if s.step < lower-i {
+				break
+			}
+		}
+	}
+	return indices
+}
+
+// bound normalizes the bound, if provided, otherwise returns the default.
+// The result lies between 0 and the length for a positive step,
+// and between -1 and one less than the length for a negative step.
+func (s *slice) bound(bound *int, def, length int) int {
+	if bound == nil {
+		return def
+	}
+	b := *bound
+	if b < 0 {
+		b += length
+	}
+	low, high := 0, length
+	if s.step < 0 {
+		low, high = -1, length-1
+	}
+	if b < low {
+		return low
+	}
+	if b > high {
+		return high
+	}
+	return b
+}
+
+func reached(limit int, results []*Jsonic) bool {
+	return limit >= 0 && len(results) >= limit
+}
@@ -0,0 +1,142 @@
+package jsonic_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func queryData(t *testing.T, j *jsonic.Jsonic, path string) []interface{} {
+	results, err := j.Query(path)
+	assert.NoError(t, err)
+	data := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		v, err := r.Get(".")
+		assert.NoError(t, err)
+		data = append(data, v)
+	}
+	return data
+}
+
+func TestQueryWildcard(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test7.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	assert.Equal(t, []interface{}{"naruto", "boruto", "sasuke"}, queryData(t, j, "store.books[*].title"))
+	assert.Equal(t, []interface{}{"naruto", "boruto", "sasuke"}, queryData(t, j, "store.books.*.title"))
+	assert.Equal(t, []interface{}{30.0}, queryData(t, j, "store.books[*].meta.id"))
+	// object keys are taken in the sorted order
+	assert.Equal(t, []interface{}{"red", 4.0}, queryData(t, j, "store.bicycle.*"))
+	assert.Equal(t, []interface{}{4.0}, queryData(t, j, "store.*.id"))
+	assert.Equal(t, []interface{}{}, queryData(t, j, "store.books[*].none"))
+	assert.Equal(t, []interface{}{}, queryData(t, j, "items[*][*]"))
+}
+
+func TestQueryDescent(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test7.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	assert.Equal(t, []interface{}{4.0, 1.0, 2.0, 3.0, 30.0}, queryData(t, j, "..id"))
+	assert.Equal(t, []interface{}{30.0}, queryData(t, j, "store..meta.id"))
+	assert.Equal(t, []interface{}{"naruto", "boruto", "sasuke"}, queryData(t, j, "store..books[*].title"))
+	assert.Equal(t, []interface{}{0.0}, queryData(t, j, "..items[0]"))
+	assert.Len(t, queryData(t, j, "store.books[2].."), 6)
+}
+
+func TestQuerySlice(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test7.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	assert.Equal(t, []interface{}{1.0, 2.0, 3.0, 4.0}, queryData(t, j, "items[1:5]"))
+	assert.Equal(t, []interface{}{0.0, 2.0, 4.0, 6.0, 8.0}, queryData(t, j, "items[::2]"))
+	assert.Equal(t, []interface{}{8.0, 9.0}, queryData(t, j, "items[-2:]"))
+	assert.Equal(t, []interface{}{0.0, 1.0}, queryData(t, j, "items[:-8]"))
+	assert.Equal(t, []interface{}{9.0, 6.0, 3.0, 0.0}, queryData(t, j, "items[::-3]"))
+	assert.Equal(t, []interface{}{5.0, 4.0}, queryData(t, j, "items[5:3:-1]"))
+	assert.Equal(t, []interface{}{}, queryData(t, j, "items[::0]"))
+	assert.Equal(t, []interface{}{}, queryData(t, j, "items[20:]"))
+	assert.Equal(t, []interface{}{"boruto", "sasuke"}, queryData(t, j, "store.books[1:].title"))
+	assert.Equal(t, []interface{}{}, queryData(t, j, "store[0:1]"))
+
+	// the steps beyond the length do not overflow
+	largest, smallest := math.MaxInt64, math.MinInt64
+	for path, expected := range map[string][]interface{}{
+		fmt.Sprintf("items[1:3:%d]", largest):                       {1.0},
+		fmt.Sprintf("items[1::%d]", largest):                        {1.0},
+		fmt.Sprintf("items[%d:%d:%d]", smallest, largest, largest):  {0.0},
+		fmt.Sprintf("items[1:0:%d]", smallest):                      {1.0},
+		fmt.Sprintf("items[1::%d]", smallest):                       {1.0},
+		fmt.Sprintf("items[%d:%d:%d]", largest, smallest, smallest): {9.0},
+	} {
+		assert.Equal(t, expected, queryData(t, j, path), path)
+	}
+}
+
+func TestQuerySingle(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test1.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	assert.Equal(t, []interface{}{"p"}, queryData(t, j, "a.x"))
+	assert.Equal(t, []interface{}{}, queryData(t, j, "a.m"))
+	results, err := j.Query(".")
+	assert.NoError(t, err)
+	assert.Equal(t, []*jsonic.Jsonic{j}, results)
+
+	results, err = j.Query(`a["b`)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+	assert.Nil(t, results)
+}
+
+func TestChildMultiple(t *testing.T) {
+	j, err := jsonic.New(readFromFile("test_data/test7.json", t))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+
+	// the first result is returned
+	s, err := j.GetString("store.books[*].title")
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", s)
+
+	i, err := j.GetInt("..meta.id")
+	assert.NoError(t, err)
+	assert.Equal(t, 30, i)
+
+	i, err = j.GetInt("..none")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrNoDataFound))
+	assert.Equal(t, 0, i)
+
+	// the children are shared with the ones from query
+	results, err := j.Query("store.books[*]")
+	assert.NoError(t, err)
+	c, err := j.Child("store.books[1]")
+	assert.NoError(t, err)
+	assert.Same(t, c, results[1])
+
+	// mutation is not allowed on multiple trees
+	err = j.Set("store.books[*].title", "naruto")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+	err = j.Delete("..id")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+	err = j.Append("items[:]", 1)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+
+	// exact wildcard key
+	err = j.Set(`store.\*`, 1)
+	assert.NoError(t, err)
+	i, err = j.GetInt(`store["*"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+}
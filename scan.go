@@ -0,0 +1,298 @@
+package jsonic
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// the scanner below walks the json data without allocating, and so is used
+// to find the values in the data without unmarshalling all of it
+
+// skipSpace returns the position of the first byte at or after i which is not a whitespace.
+func skipSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// maxDepth is the maximum nesting of the objects and the arrays, same as the one of encoding/json,
+// beyond which the json data is not valid, so that it cannot exhaust the stack.
+const maxDepth = 10000
+
+// skipValue returns the position just after the json value beginning at i,
+// reporting whether there is a valid json value there.
+//
+// The depth is the number of the objects and the arrays containing the value. The numbers
+// which do not fit in a float64 are considered valid only in case they are kept as they
+// are, same as it is done while unmarshalling them.
+func skipValue(data []byte, i, depth int, useNumber bool) (int, bool) {
+	if i >= len(data) {
+		return i, false
+	}
+	switch data[i] {
+	case '"':
+		return skipString(data, i)
+	case '{':
+		return skipObject(data, i, depth+1, useNumber)
+	case '[':
+		return skipArray(data, i, depth+1, useNumber)
+	case 't':
+		return skipLiteral(data, i, "true")
+	case 'f':
+		return skipLiteral(data, i, "false")
+	case 'n':
+		return skipLiteral(data, i, "null")
+	}
+	return skipNumber(data, i, useNumber)
+}
+
+func skipObject(data []byte, i, depth int, useNumber bool) (int, bool) {
+	if depth > maxDepth {
+		return i, false
+	}
+	i = skipSpace(data, i+1)
+	if i < len(data) && data[i] == '}' {
+		return i + 1, true
+	}
+	for {
+		if i >= len(data) || data[i] != '"' {
+			return i, false
+		}
+		end, ok := skipString(data, i)
+		if !ok {
+			return end, false
+		}
+		i = skipSpace(data, end)
+		if i >= len(data) || data[i] != ':' {
+			return i, false
+		}
+		i, ok = skipValue(data, skipSpace(data, i+1), depth, useNumber)
+		if !ok {
+			return i, false
+		}
+		i = skipSpace(data, i)
+		if i >= len(data) {
+			return i, false
+		}
+		switch data[i] {
+		case '}':
+			return i + 1, true
+		case ',':
+			i = skipSpace(data, i+1)
+		default:
+			return i, false
+		}
+	}
+}
+
+func skipArray(data []byte, i, depth int, useNumber bool) (int, bool) {
+	if depth > maxDepth {
+		return i, false
+	}
+	i = skipSpace(data, i+1)
+	if i < len(data) && data[i] == ']' {
+		return i + 1, true
+	}
+	for {
+		var ok bool
+		i, ok = skipValue(data, i, depth, useNumber)
+		if !ok {
+			return i, false
+		}
+		i = skipSpace(data, i)
+		if i >= len(data) {
+			return i, false
+		}
+		switch data[i] {
+		case ']':
+			return i + 1, true
+		case ',':
+			i = skipSpace(data, i+1)
+		default:
+			return i, false
+		}
+	}
+}
+
+// skipString returns the position just after the closing quote of the string beginning at i.
+func skipString(data []byte, i int) (int, bool) {
+	for i++; i < len(data); i++ {
+		switch c := data[i]; {
+		case c == '"':
+			return i + 1, true
+		case c < 0x20:
+			return i, false
+		case c == '\\':
+			i++
+			if i >= len(data) {
+				return i, false
+			}
+			switch data[i] {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+			case 'u':
+				if i+4 >= len(data) {
+					return len(data), false
+				}
+				for _, h := range data[i+1 : i+5] {
+					if !isHex(h) {
+						return i, false
+					}
+				}
+				i += 4
+			default:
+				return i, false
+			}
+		}
+	}
+	return i, false
+}
+
+func skipLiteral(data []byte, i int, literal string) (int, bool) {
+	end := i + len(literal)
+	if end > len(data) || string(data[i:end]) != literal {
+		return i, false
+	}
+	return end, true
+}
+
+func skipNumber(data []byte, i int, useNumber bool) (int, bool) {
+	start := i
+	if i < len(data) && data[i] == '-' {
+		i++
+	}
+	digits := i
+	for i < len(data) && isDigit(data[i]) {
+		i++
+	}
+	if i == digits || (data[digits] == '0' && i-digits > 1) {
+		return i, false
+	}
+	exponent := false
+	if i < len(data) && data[i] == '.' {
+		i++
+		fraction := i
+		for i < len(data) && isDigit(data[i]) {
+			i++
+		}
+		if i == fraction {
+			return i, false
+		}
+	}
+	if i < len(data) && (data[i] == 'e' || data[i] == 'E') {
+		exponent = true
+		i++
+		if i < len(data) && (data[i] == '+' || data[i] == '-') {
+			i++
+		}
+		e := i
+		for i < len(data) && isDigit(data[i]) {
+			i++
+		}
+		if i == e {
+			return i, false
+		}
+	}
+	if !useNumber && (exponent || i-start > 300) {
+		// only such a number can be too large for a float64
+		if _, err := strconv.ParseFloat(string(data[start:i]), 64); err != nil {
+			return start, false
+		}
+	}
+	return i, true
+}
+
+// isValid reports whether the data is a single valid json value.
+func isValid(data []byte, useNumber bool) bool {
+	end, ok := skipValue(data, skipSpace(data, 0), 0, useNumber)
+	return ok && skipSpace(data, end) == len(data)
+}
+
+// unquote returns the string encoded in the valid json string provided.
+func unquote(data []byte) string {
+	s := data[1 : len(data)-1]
+	if bytes.IndexByte(s, '\\') < 0 && utf8.Valid(s) {
+		return string(s)
+	}
+	return string(appendUnquoted(nil, s))
+}
+
+// appendUnquoted appends the string within the quotes of a valid json string to the bytes provided,
+// in the same way as the encoding/json package does, replacing the invalid characters with U+FFFD.
+func appendUnquoted(dst, s []byte) []byte {
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\':
+			i++
+			switch s[i] {
+			case 'b':
+				dst = append(dst, '\b')
+			case 'f':
+				dst = append(dst, '\f')
+			case 'n':
+				dst = append(dst, '\n')
+			case 'r':
+				dst = append(dst, '\r')
+			case 't':
+				dst = append(dst, '\t')
+			case 'u':
+				r := hexRune(s[i+1 : i+5])
+				i += 5
+				if utf16.IsSurrogate(r) {
+					if i+6 <= len(s) && s[i] == '\\' && s[i+1] == 'u' {
+						if d := utf16.DecodeRune(r, hexRune(s[i+2:i+6])); d != utf8.RuneError {
+							r = d
+							i += 6
+						} else {
+							r = utf8.RuneError
+						}
+					} else {
+						r = utf8.RuneError
+					}
+				}
+				dst = utf8.AppendRune(dst, r)
+				continue
+			default:
+				// the quote, the backslash and the slash are as they are
+				dst = append(dst, s[i])
+			}
+			i++
+		case c < utf8.RuneSelf:
+			dst = append(dst, c)
+			i++
+		default:
+			r, size := utf8.DecodeRune(s[i:])
+			dst = utf8.AppendRune(dst, r)
+			i += size
+		}
+	}
+	return dst
+}
+
+func hexRune(h []byte) rune {
+	var r rune
+	for _, c := range h {
+		switch {
+		case c >= '0' && c <= '9':
+			c -= '0'
+		case c >= 'a' && c <= 'f':
+			c = c - 'a' + 10
+		default:
+			c = c - 'A' + 10
+		}
+		r = r<<4 | rune(c)
+	}
+	return r
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,395 @@
+package jsonic
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Stream evaluates the registered paths on the json data while it is being read,
+// so that a large json data can be processed without holding all of it in memory.
+//
+// Only the values which are needed are unmarshalled, everything else is skipped
+// as it is read. A value at any of the paths is passed to the function registered
+// for it as soon as it is read completely.
+type Stream struct {
+	decoder  *json.Decoder
+	opts     *options
+	handlers []streamHandler
+	// the reference tokens of the json pointer of the value being read
+	location []string
+}
+
+// StreamFunc is called with each of the json trees found at the registered path,
+// along with its json pointer in the json data. Returning an error stops the stream.
+type StreamFunc func(pointer string, j *Jsonic) error
+
+type streamHandler struct {
+	path *Path
+	f    StreamFunc
+}
+
+// streamState is a registered path resolved till the element at the index,
+// on the value being read.
+type streamState struct {
+	handler int
+	index   int
+}
+
+// NewFromReader is used to create a new parser for the JSON data read from the reader,
+// configured with the options provided.
+func NewFromReader(r io.Reader, opts ...Option) (*Jsonic, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithOptions(data, opts...)
+}
+
+// NewStream is used to create a new stream for the JSON data read from the reader,
+// configured with the options provided.
+//
+// The paths should be registered before running it.
+func NewStream(r io.Reader, opts ...Option) *Stream {
+	decoder := json.NewDecoder(r)
+	// the numbers are converted only when needed
+	decoder.UseNumber()
+	return &Stream{decoder: decoder, opts: newOptions(opts)}
+}
+
+// Register is used to call the function provided with each of the json trees at the path.
+//
+// The path is the same as the one accepted by Query, and all the json trees
+// selected by it are passed to the function, in the order they appear in the
+// json data. Note that a key which can be formed by joining the path elements
+// in multiple ways, like a.b for the path a.b.c, is matched in each of these ways,
+// instead of the one preferred by Query.
+//
+// Most of the path elements are resolved while reading the json data. Only the negative
+// indices, the slices with the negative bounds or step, and the filters need the array
+// or the object they are resolved on to be unmarshalled completely.
+func (s *Stream) Register(path string, f StreamFunc) error {
+	p, err := Compile(path)
+	if err != nil {
+		return err
+	}
+	return s.RegisterPath(p, f)
+}
+
+// RegisterPath is used to call the function provided with each of the json trees at the compiled path.
+func (s *Stream) RegisterPath(p *Path, f StreamFunc) error {
+	if p.err != nil {
+		return p.err
+	}
+	s.handlers = append(s.handlers, streamHandler{path: p, f: f})
+	return nil
+}
+
+// Run reads the json data, calling the registered functions with the json trees
+// at their paths.
+//
+// It returns an error in case the data is not a valid json, or any of the functions
+// returns an error, in which case the rest of the data is not read.
+func (s *Stream) Run() error {
+	states := make([]streamState, len(s.handlers))
+	for i := range s.handlers {
+		states[i] = streamState{handler: i}
+	}
+	err := s.value(states)
+	if err != nil {
+		return err
+	}
+	// make sure there is nothing after the json value
+	_, err = s.decoder.Token()
+	if err == io.EOF {
+		return nil
+	}
+	if err == nil {
+		return ErrInvalidJSON
+	}
+	return err
+}
+
+// value reads the next json value, resolving the paths in the states provided on it.
+func (s *Stream) value(states []streamState) error {
+	t, err := s.token()
+	if err != nil {
+		return err
+	}
+	if len(states) == 0 {
+		return s.skip(t)
+	}
+	// the ones after the descents can also be resolved on this value
+	expanded := s.expand(states)
+	if s.needed(expanded, t) {
+		data, err := s.build(t)
+		if err != nil {
+			return err
+		}
+		return s.emit(states, new(data, s.opts))
+	}
+	switch t {
+	case json.Delim('{'):
+		for s.decoder.More() {
+			k, err := s.token()
+			if err != nil {
+				return err
+			}
+			key := k.(string)
+			s.location = append(s.location, escapeToken(key))
+			err = s.value(s.fromObject(expanded, key))
+			if err != nil {
+				return err
+			}
+			s.location = s.location[:len(s.location)-1]
+		}
+	case json.Delim('['):
+		for index := 0; s.decoder.More(); index++ {
+			s.location = append(s.location, strconv.Itoa(index))
+			err = s.value(s.fromArray(expanded, index))
+			if err != nil {
+				return err
+			}
+			s.location = s.location[:len(s.location)-1]
+		}
+	default:
+		// nothing can be resolved further on the other values
+		return nil
+	}
+	// the closing delimiter
+	_, err = s.token()
+	return err
+}
+
+// expand adds the states for the path elements following the descents,
+// as a descent also selects the value it is resolved on.
+func (s *Stream) expand(states []streamState) []streamState {
+	expanded := states
+	for i := 0; i < len(expanded); i++ {
+		st := expanded[i]
+		elements := s.handlers[st.handler].path.elements
+		if st.index < len(elements) && elements[st.index].nature == natureDescent {
+			if len(expanded) == len(states) {
+				// not to modify the states provided
+				expanded = append(make([]streamState, 0, 2*len(states)), states...)
+			}
+			expanded = append(expanded, streamState{handler: st.handler, index: st.index + 1})
+		}
+	}
+	return expanded
+}
+
+// needed reports whether the value beginning with the token has to be unmarshalled completely,
+// either as it is at any of the paths, or the next path element cannot be resolved otherwise.
+func (s *Stream) needed(states []streamState, t json.Token) bool {
+	for _, st := range states {
+		elements := s.handlers[st.handler].path.elements
+		if st.index == len(elements) {
+			return true
+		}
+		e := elements[st.index]
+		if e.nature == natureFilter && (t == json.Delim('{') || t == json.Delim('[')) {
+			return true
+		}
+		if t != json.Delim('[') {
+			continue
+		}
+		if e.nature == natureSlice && !e.slice.forward() {
+			return true
+		}
+		if index, err := e.arrayIndex(); e.singular() && err == nil && index < 0 {
+			// counted from the end of the array
+			return true
+		}
+	}
+	return false
+}
+
+// fromObject returns the states resolved on the value of the key in the object.
+func (s *Stream) fromObject(states []streamState, key string) []streamState {
+	var next []streamState
+	for _, st := range states {
+		elements := s.handlers[st.handler].path.elements
+		switch elements[st.index].nature {
+		case natureDescent:
+			next = append(next, st)
+		case natureWildcard:
+			next = append(next, streamState{handler: st.handler, index: st.index + 1})
+		case natureKey, natureIndex, natureToken:
+			// the key might be formed by joining the path elements, same as in nextCandidate
+			current := ""
+			for i := st.index; i < len(elements); i++ {
+				p := elements[i]
+				if i > st.index {
+					if !p.joinable() {
+						break
+					}
+					current += p.separator
+				}
+				current += p.key
+				if current == key {
+					next = append(next, streamState{handler: st.handler, index: i + 1})
+				}
+				if !p.joinable() || !strings.HasPrefix(key, current) {
+					break
+				}
+			}
+		}
+	}
+	return next
+}
+
+// fromArray returns the states resolved on the element at the index in the array.
+func (s *Stream) fromArray(states []streamState, index int) []streamState {
+	var next []streamState
+	for _, st := range states {
+		e := s.handlers[st.handler].path.elements[st.index]
+		switch e.nature {
+		case natureDescent:
+			next = append(next, st)
+		case natureWildcard:
+			next = append(next, streamState{handler: st.handler, index: st.index + 1})
+		case natureSlice:
+			if e.slice.contains(index) {
+				next = append(next, streamState{handler: st.handler, index: st.index + 1})
+			}
+		case natureKey, natureIndex, natureToken:
+			if i, err := e.arrayIndex(); err == nil && i == index {
+				next = append(next, streamState{handler: st.handler, index: st.index + 1})
+			}
+		}
+	}
+	return next
+}
+
+// emit resolves the rest of the paths in the states on the json tree unmarshalled,
+// and calls the functions with the json trees found.
+func (s *Stream) emit(states []streamState, j *Jsonic) error {
+	pointer := s.pointer()
+	for _, st := range states {
+		h := s.handlers[st.handler]
+		var results []*Jsonic
+		if h.path.path == dot || h.path.path == empty {
+			results = []*Jsonic{j.getDotOrEmptyChild(h.path.path)}
+		} else {
+			results, _ = j.children(h.path.elements[st.index:], -1, nil)
+		}
+		for _, result := range results {
+			err := h.f(pointer+result.Pointer(), result)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pointer returns the json pointer of the value being read.
+func (s *Stream) pointer() string {
+	if len(s.location) == 0 {
+		return empty
+	}
+	return slash + strings.Join(s.location, slash)
+}
+
+// build unmarshals the json value beginning with the token.
+func (s *Stream) build(t json.Token) (interface{}, error) {
+	switch t {
+	case json.Delim('{'):
+		object := make(map[string]interface{})
+		for s.decoder.More() {
+			k, err := s.token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := s.token()
+			if err != nil {
+				return nil, err
+			}
+			object[k.(string)], err = s.build(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, err := s.token()
+		return object, err
+	case json.Delim('['):
+		array := make([]interface{}, 0)
+		for s.decoder.More() {
+			v, err := s.token()
+			if err != nil {
+				return nil, err
+			}
+			element, err := s.build(v)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, element)
+		}
+		_, err := s.token()
+		return array, err
+	}
+	n, ok := t.(json.Number)
+	if !ok || s.opts.useNumber {
+		return t, nil
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		// same as it is done while unmarshalling
+		return nil, &json.UnmarshalTypeError{Value: "number " + string(n), Type: reflect.TypeOf(f),
+			Offset: s.decoder.InputOffset()}
+	}
+	return f, nil
+}
+
+// skip reads the rest of the json value beginning with the token.
+func (s *Stream) skip(t json.Token) error {
+	depth := 0
+	for {
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+		var err error
+		t, err = s.token()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// token returns the next token, where the end of the data is unexpected.
+func (s *Stream) token() (json.Token, error) {
+	t, err := s.decoder.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return t, err
+}
+
+// forward reports whether the slice can be resolved without knowing the length of the array.
+func (s *slice) forward() bool {
+	if s == nil {
+		return true
+	}
+	return s.step > 0 && (s.start == nil || *s.start >= 0) && (s.end == nil || *s.end >= 0)
+}
+
+// contains reports whether the index is selected by the forward slice.
+func (s *slice) contains(index int) bool {
+	if s == nil {
+		return true
+	}
+	start := 0
+	if s.start != nil {
+		start = *s.start
+	}
+	return index >= start && (s.end == nil || index < *s.end) && (index-start)%s.step == 0
+}
@@ -0,0 +1,205 @@
+package jsonic_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/jsonic"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+func TestNewFromReader(t *testing.T) {
+	data := readFromFile("test_data/test2.json", t)
+	expected, err := jsonic.New(data)
+	assert.NoError(t, err)
+
+	j, err := jsonic.NewFromReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.NotNil(t, j)
+	e, _ := expected.Get(".")
+	a, _ := j.Get(".")
+	assert.Equal(t, e, a)
+
+	j, err = jsonic.NewFromReader(strings.NewReader(`{"id": 9007199254740993}`), jsonic.UseNumber())
+	assert.NoError(t, err)
+	id, err := j.GetInt64("id")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), id)
+
+	j, err = jsonic.NewFromReader(strings.NewReader(`{"a": `))
+	assert.Nil(t, j)
+	assert.Error(t, err)
+}
+
+// collect returns the pointers and the values found by the stream at the path.
+func collect(t *testing.T, data []byte, path string, opts ...jsonic.Option) ([]string, error) {
+	var found []string
+	s := jsonic.NewStream(bytes.NewReader(data), opts...)
+	err := s.Register(path, func(pointer string, j *jsonic.Jsonic) error {
+		b, err := j.Bytes()
+		assert.NoError(t, err)
+		found = append(found, pointer+" "+string(b))
+		return nil
+	})
+	assert.NoError(t, err)
+	return found, s.Run()
+}
+
+func TestStreamSameAsQuery(t *testing.T) {
+	cases := map[string][]string{
+		"test_data/test1.json": {".", "a", "a.arr[0].c.d.e", "c", "a.arr[-1].a", "x", "..e", "*"},
+		"test_data/test2.json": {"a", "e[1]", "e[-1]", "h[*]", "i.naruto", "e[5]", "[0]", "*", "..*"},
+		"test_data/test9.json": {"items[*].name", "items[?(@.price > 10)].id", "..rank", "items[1:3].tags",
+			"items[::2].id", "items[-2:].id", "scores[::-1]", "items[?(@.meta.rank == 'genin')].name",
+			"items[2].a\\.b", "/items/1/isbn", "ranks.*.level", "..tags[0]", "items..meta", "ranks[?(@.level > 2)]"},
+	}
+	for file, paths := range cases {
+		data := readFromFile(file, t)
+		j, err := jsonic.New(data)
+		assert.NoError(t, err)
+		for _, path := range paths {
+			results, err := j.Query(path)
+			assert.NoError(t, err)
+			var expected []string
+			for _, result := range results {
+				b, err := result.Bytes()
+				assert.NoError(t, err)
+				expected = append(expected, result.Pointer()+" "+string(b))
+			}
+			actual, err := collect(t, data, path)
+			assert.NoError(t, err, file+" "+path)
+			// the keys of the objects are in the order they are read
+			sort.Strings(expected)
+			sort.Strings(actual)
+			assert.Equal(t, expected, actual, file+" "+path)
+		}
+	}
+}
+
+func TestStreamOrder(t *testing.T) {
+	data := []byte(`{"b": {"id": 1}, "a": [{"id": 2}, {"x": {"id": 3}}], "id": 4}`)
+	found, err := collect(t, data, "..id")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/b/id 1", "/a/0/id 2", "/a/1/x/id 3", "/id 4"}, found)
+}
+
+func TestStreamJoinedKeys(t *testing.T) {
+	// each of the ways of forming the keys is matched
+	found, err := collect(t, readFromFile("test_data/test1.json", t), "a.x.y")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`/a.x/y "q"`, `/a.x.y {"z":"r"}`}, found)
+}
+
+func TestStreamNumbers(t *testing.T) {
+	data := []byte(`{"id": 9007199254740993, "f": 1.5}`)
+	var id int64
+	var f float64
+	s := jsonic.NewStream(bytes.NewReader(data), jsonic.UseNumber())
+	assert.NoError(t, s.Register("id", func(pointer string, j *jsonic.Jsonic) error {
+		var err error
+		id, err = j.GetInt64(".")
+		return err
+	}))
+	assert.NoError(t, s.Register("f", func(pointer string, j *jsonic.Jsonic) error {
+		var err error
+		f, err = j.GetFloat64(".")
+		return err
+	}))
+	assert.NoError(t, s.Run())
+	assert.Equal(t, int64(9007199254740993), id)
+	assert.Equal(t, 1.5, f)
+
+	found, err := collect(t, []byte(`[1e400]`), "[0]")
+	assert.Error(t, err)
+	assert.Empty(t, found)
+	found, err = collect(t, []byte(`[1, 1e400]`), "[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/0 1"}, found)
+}
+
+func TestStreamReadsIncrementally(t *testing.T) {
+	data := largeJSON()
+	r := &countingReader{r: bytes.NewReader(data)}
+	s := jsonic.NewStream(r)
+	var count int
+	var readAtFirst int
+	assert.NoError(t, s.Register("items[*].id", func(pointer string, j *jsonic.Jsonic) error {
+		if count == 0 {
+			readAtFirst = r.read
+		}
+		count++
+		return nil
+	}))
+	var meta string
+	assert.NoError(t, s.RegisterPath(jsonic.MustCompile("meta.id"), func(pointer string, j *jsonic.Jsonic) error {
+		var err error
+		meta, err = j.GetString(".")
+		return err
+	}))
+	assert.NoError(t, s.Run())
+	assert.Equal(t, 10000, count)
+	assert.Equal(t, "naruto", meta)
+	assert.Less(t, readAtFirst, len(data)/100)
+}
+
+func TestStreamStop(t *testing.T) {
+	stop := errors.New("stop")
+	r := &countingReader{r: bytes.NewReader(largeJSON())}
+	s := jsonic.NewStream(r)
+	count := 0
+	assert.NoError(t, s.Register("items[*]", func(pointer string, j *jsonic.Jsonic) error {
+		count++
+		if pointer == "/items/9" {
+			return stop
+		}
+		return nil
+	}))
+	assert.Equal(t, stop, s.Run())
+	assert.Equal(t, 10, count)
+	assert.Less(t, r.read, len(largeJSON())/100)
+}
+
+func TestStreamErrors(t *testing.T) {
+	s := jsonic.NewStream(strings.NewReader(`{}`))
+	err := s.Register(`a["`, func(string, *jsonic.Jsonic) error { return nil })
+	var pathErr *jsonic.PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidPath))
+
+	for _, data := range []string{``, `{"a": 1`, `{"a" 1}`, `[1, 2,]`, `{"a": [1}`, `{"a": 1} x`} {
+		found, err := collect(t, []byte(data), "b")
+		assert.Error(t, err, data)
+		assert.Empty(t, found, data)
+	}
+	found, err := collect(t, []byte(`{"a": 1} {}`), "a")
+	assert.True(t, errors.Is(err, jsonic.ErrInvalidJSON))
+	assert.Equal(t, []string{"/a 1"}, found)
+	// the errors in the values which are skipped are also reported
+	_, err = collect(t, []byte(`{"a": 1, "b": [tru]}`), "a")
+	assert.Error(t, err)
+}
+
+func BenchmarkStreamLarge(b *testing.B) {
+	data := largeJSON()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := jsonic.NewStream(bytes.NewReader(data))
+		_ = s.Register("items[*].id", func(string, *jsonic.Jsonic) error { return nil })
+		_ = s.Run()
+	}
+}
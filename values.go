@@ -0,0 +1,93 @@
+package jsonic
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+)
+
+// isNumber reports whether the data is a json number.
+func isNumber(data interface{}) bool {
+	switch data.(type) {
+	case float64, json.Number:
+		return true
+	}
+	return false
+}
+
+// compareNumbers compares the json numbers, and returns -1, 0 or 1
+// depending on whether the first one is less than, equal to or greater than the other.
+func compareNumbers(a, b interface{}) int {
+	x, okX := a.(json.Number)
+	y, okY := b.(json.Number)
+	if okX && okY {
+		// both are exact, so compare them exactly
+		var rx, ry big.Rat
+		_, okX = rx.SetString(string(x))
+		_, okY = ry.SetString(string(y))
+		if okX && okY {
+			return rx.Cmp(&ry)
+		}
+	}
+	fx, fy := numberAsFloat64(a), numberAsFloat64(b)
+	switch {
+	case fx < fy:
+		return -1
+	case fx > fy:
+		return 1
+	}
+	return 0
+}
+
+func numberAsFloat64(data interface{}) float64 {
+	switch v := data.(type) {
+	case float64:
+		return v
+	case json.Number:
+		// on overflow, this is the infinity with the correct sign
+		f, _ := strconv.ParseFloat(string(v), 64)
+		return f
+	}
+	return 0
+}
+
+// equalValues reports whether the json values are equal, comparing the numbers
+// numerically and the objects irrespective of the order of their keys.
+func equalValues(a, b interface{}) bool {
+	switch x := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	case string:
+		y, ok := b.(string)
+		return ok && x == y
+	case float64, json.Number:
+		return isNumber(b) && compareNumbers(a, b) == 0
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equalValues(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equalValues(v, w) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}